@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 // BidBetterBefore returns the time when the next bid better be received, considering the delay and bid simulation.
@@ -21,3 +22,15 @@ func BidMustBefore(parentHeader *types.Header, blockPeriod uint64, delayLeftOver
 	nextHeaderTime = nextHeaderTime.Add(-delayLeftOver)
 	return nextHeaderTime
 }
+
+// MarkBid increases the bid counter of the given name by one. The counters are shared by
+// the API receiving the bids and the miner judging them.
+func MarkBid(name string) {
+	metrics.GetOrRegisterCounter("bid/"+name, nil).Inc(1)
+}
+
+// RejectBid counts the rejected bid by reason and returns the error as is.
+func RejectBid(reason string, err error) error {
+	MarkBid("rejected/" + reason)
+	return err
+}
@@ -44,7 +44,7 @@ func (b *BidArgs) ToBid(builder common.Address, signer Signer) (*Bid, error) {
 		var payBidTx = new(Transaction)
 		err = payBidTx.UnmarshalBinary(b.PayBidTx)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to decode payBidTx, %v", err)
 		}
 
 		txs = append(txs, payBidTx)
@@ -102,6 +102,9 @@ func (b *RawBid) DecodeTxs(signer Signer) ([]*Transaction, error) {
 		return tx, nil
 	}
 
+	// txs are dispatched in order, so every tx before a failed one has been decoded
+	// and the lowest failed index is the first invalid tx of the bid.
+	txErrs := make([]error, len(b.Txs))
 	errChan := make(chan error, TxDecodeConcurrencyForPerBid)
 	for i := 0; i < TxDecodeConcurrencyForPerBid; i++ {
 		go func() {
@@ -115,6 +118,7 @@ func (b *RawBid) DecodeTxs(signer Signer) ([]*Transaction, error) {
 				txBytes := b.Txs[txIndex]
 				tx, err := decode(txBytes)
 				if err != nil {
+					txErrs[txIndex] = err
 					errChan <- err
 					return
 				}
@@ -130,10 +134,18 @@ func (b *RawBid) DecodeTxs(signer Signer) ([]*Transaction, error) {
 
 	close(txChan)
 
+	var failed bool
 	for i := 0; i < TxDecodeConcurrencyForPerBid; i++ {
-		err := <-errChan
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode tx, %v", err)
+		if err := <-errChan; err != nil {
+			failed = true
+		}
+	}
+
+	if failed {
+		for txIndex, err := range txErrs {
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode tx at index %d, %v", txIndex, err)
+			}
 		}
 	}
 
@@ -181,6 +193,7 @@ type BidIssue struct {
 type MevParams struct {
 	ValidatorCommission   uint64 // 100 means 1%
 	BidSimulationLeftOver time.Duration
+	DelayLeftOver         time.Duration // Time reserved by the validator to finalize a block
 	GasCeil               uint64
-	BuilderFeeCeil        *big.Int
+	BuilderFeeCeil        *big.Int // nil means no ceil
 }
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRawBidDecodeTxs(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("could not generate key: %v", err)
+	}
+	signer := LatestSignerForChainID(big.NewInt(1))
+
+	rawTxs := make([]hexutil.Bytes, 0, 12)
+	for i := uint64(0); i < 12; i++ {
+		tx := MustSignNewTx(key, signer, &LegacyTx{
+			Nonce:    i,
+			To:       &common.Address{0x01},
+			Gas:      21000,
+			GasPrice: big.NewInt(1),
+		})
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			t.Fatalf("could not encode tx: %v", err)
+		}
+		rawTxs = append(rawTxs, raw)
+	}
+
+	rawBid := &RawBid{Txs: rawTxs}
+	txs, err := rawBid.DecodeTxs(signer)
+	if err != nil {
+		t.Fatalf("failed to decode valid txs: %v", err)
+	}
+	for i, tx := range txs {
+		if tx.Nonce() != uint64(i) {
+			t.Fatalf("tx %d: nonce mismatch, have %d", i, tx.Nonce())
+		}
+	}
+
+	// corrupt two txs, only the first one is reported
+	malformed := make([]hexutil.Bytes, len(rawTxs))
+	copy(malformed, rawTxs)
+	malformed[7] = hexutil.Bytes{0x01, 0x02}
+	malformed[9] = hexutil.Bytes{0x03}
+
+	rawBid = &RawBid{Txs: malformed}
+	_, err = rawBid.DecodeTxs(signer)
+	if err == nil {
+		t.Fatal("expected decode error")
+	}
+	if !strings.Contains(err.Error(), "at index 7,") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -170,3 +172,19 @@ func (api *AdminAPI) AddBuilder(builder common.Address, url string) error {
 func (api *AdminAPI) RemoveBuilder(builder common.Address) error {
 	return api.eth.APIBackend.RemoveBuilder(builder)
 }
+
+// SetBuilderFeeCeil sets the maximum builder fee the validator accepts in a bid,
+// null removes the ceil.
+func (api *AdminAPI) SetBuilderFeeCeil(ceil *hexutil.Big) {
+	api.eth.APIBackend.SetBuilderFeeCeil((*big.Int)(ceil))
+}
+
+// AddDeniedTxTarget makes the validator reject bids containing a tx to the given address.
+func (api *AdminAPI) AddDeniedTxTarget(target common.Address) {
+	api.eth.APIBackend.AddDeniedTxTarget(target)
+}
+
+// RemoveDeniedTxTarget makes the validator accept bids containing a tx to the given address again.
+func (api *AdminAPI) RemoveDeniedTxTarget(target common.Address) {
+	api.eth.APIBackend.RemoveDeniedTxTarget(target)
+}
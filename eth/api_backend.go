@@ -484,6 +484,18 @@ func (b *EthAPIBackend) RemoveBuilder(builder common.Address) error {
 	return b.Miner().RemoveBuilder(builder)
 }
 
+func (b *EthAPIBackend) SetBuilderFeeCeil(ceil *big.Int) {
+	b.Miner().SetBuilderFeeCeil(ceil)
+}
+
+func (b *EthAPIBackend) AddDeniedTxTarget(target common.Address) {
+	b.Miner().AddDeniedTxTarget(target)
+}
+
+func (b *EthAPIBackend) RemoveDeniedTxTarget(target common.Address) {
+	b.Miner().RemoveDeniedTxTarget(target)
+}
+
 func (b *EthAPIBackend) SendBid(ctx context.Context, bid *types.BidArgs) (common.Hash, error) {
 	return b.Miner().SendBid(ctx, bid)
 }
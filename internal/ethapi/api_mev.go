@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bidutil"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -28,12 +29,14 @@ func NewMevAPI(b Backend) *MevAPI {
 // If mev is not running or bid is invalid, return error.
 // Otherwise, creates a builder bid for the given argument, submit it to the miner.
 func (m *MevAPI) SendBid(ctx context.Context, args types.BidArgs) (common.Hash, error) {
+	bidutil.MarkBid("received")
+
 	if !m.b.MevRunning() {
-		return common.Hash{}, types.ErrMevNotRunning
+		return common.Hash{}, bidutil.RejectBid("notrunning", types.ErrMevNotRunning)
 	}
 
 	if !m.b.MinerInTurn() {
-		return common.Hash{}, types.ErrMevNotInTurn
+		return common.Hash{}, bidutil.RejectBid("notinturn", types.ErrMevNotInTurn)
 	}
 
 	var (
@@ -42,55 +45,55 @@ func (m *MevAPI) SendBid(ctx context.Context, args types.BidArgs) (common.Hash,
 	)
 
 	if rawBid == nil {
-		return common.Hash{}, types.NewInvalidBidError("rawBid should not be nil")
+		return common.Hash{}, bidutil.RejectBid("nobid", types.NewInvalidBidError("rawBid should not be nil"))
 	}
 
 	// only support bidding for the next block not for the future block
 	if rawBid.BlockNumber != currentHeader.Number.Uint64()+1 {
-		return common.Hash{}, types.NewInvalidBidError("stale block number or block in future")
+		return common.Hash{}, bidutil.RejectBid("blocknumber", types.NewInvalidBidError("stale block number or block in future"))
 	}
 
 	if rawBid.ParentHash != currentHeader.Hash() {
-		return common.Hash{}, types.NewInvalidBidError(
-			fmt.Sprintf("non-aligned parent hash: %v", currentHeader.Hash()))
+		return common.Hash{}, bidutil.RejectBid("parent", types.NewInvalidBidError(
+			fmt.Sprintf("non-aligned parent hash: %v", currentHeader.Hash())))
 	}
 
 	if rawBid.GasFee == nil || rawBid.GasFee.Cmp(common.Big0) == 0 || rawBid.GasUsed == 0 {
-		return common.Hash{}, types.NewInvalidBidError("empty gasFee or empty gasUsed")
+		return common.Hash{}, bidutil.RejectBid("gasfee", types.NewInvalidBidError("empty gasFee or empty gasUsed"))
 	}
 
 	if rawBid.BuilderFee != nil {
 		builderFee := rawBid.BuilderFee
 		if builderFee.Cmp(common.Big0) < 0 {
-			return common.Hash{}, types.NewInvalidBidError("builder fee should not be less than 0")
+			return common.Hash{}, bidutil.RejectBid("builderfee", types.NewInvalidBidError("builder fee should not be less than 0"))
 		}
 
 		if builderFee.Cmp(common.Big0) == 0 {
 			if len(args.PayBidTx) != 0 || args.PayBidTxGasUsed != 0 {
-				return common.Hash{}, types.NewInvalidPayBidTxError("payBidTx should be nil when builder fee is 0")
+				return common.Hash{}, bidutil.RejectBid("paybidtx", types.NewInvalidPayBidTxError("payBidTx should be nil when builder fee is 0"))
 			}
 		}
 
 		if builderFee.Cmp(rawBid.GasFee) >= 0 {
-			return common.Hash{}, types.NewInvalidBidError("builder fee must be less than gas fee")
+			return common.Hash{}, bidutil.RejectBid("builderfee", types.NewInvalidBidError("builder fee must be less than gas fee"))
 		}
 
 		if builderFee.Cmp(common.Big0) > 0 {
 			// payBidTx can be nil when validator and builder take some other settlement
 
 			if args.PayBidTxGasUsed > TransferTxGasLimit {
-				return common.Hash{}, types.NewInvalidBidError(
-					fmt.Sprintf("transfer tx gas used must be no more than %v", TransferTxGasLimit))
+				return common.Hash{}, bidutil.RejectBid("paybidtx", types.NewInvalidBidError(
+					fmt.Sprintf("transfer tx gas used must be no more than %v", TransferTxGasLimit)))
 			}
 
 			if (len(args.PayBidTx) == 0 && args.PayBidTxGasUsed != 0) ||
 				(len(args.PayBidTx) != 0 && args.PayBidTxGasUsed == 0) {
-				return common.Hash{}, types.NewInvalidPayBidTxError("non-aligned payBidTx and payBidTxGasUsed")
+				return common.Hash{}, bidutil.RejectBid("paybidtx", types.NewInvalidPayBidTxError("non-aligned payBidTx and payBidTxGasUsed"))
 			}
 		}
 	} else {
 		if len(args.PayBidTx) != 0 || args.PayBidTxGasUsed != 0 {
-			return common.Hash{}, types.NewInvalidPayBidTxError("payBidTx should be nil when builder fee is nil")
+			return common.Hash{}, bidutil.RejectBid("paybidtx", types.NewInvalidPayBidTxError("payBidTx should be nil when builder fee is nil"))
 		}
 	}
 
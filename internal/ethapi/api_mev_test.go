@@ -0,0 +1,93 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// mevBackendMock is an in-turn validator running mev, which accepts any bid passed to it.
+type mevBackendMock struct {
+	*backendMock
+	running bool
+	inTurn  bool
+}
+
+func (b *mevBackendMock) MevRunning() bool  { return b.running }
+func (b *mevBackendMock) MinerInTurn() bool { return b.inTurn }
+func (b *mevBackendMock) SendBid(ctx context.Context, bid *types.BidArgs) (common.Hash, error) {
+	return bid.RawBid.Hash(), nil
+}
+
+func TestMevAPISendBidMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	backend := &mevBackendMock{backendMock: newBackendMock(), running: true, inTurn: true}
+	api := NewMevAPI(backend)
+
+	count := func(name string) int64 {
+		return metrics.GetOrRegisterCounter("bid/"+name, nil).Snapshot().Count()
+	}
+	newBidArgs := func(modify func(args *types.BidArgs)) types.BidArgs {
+		args := types.BidArgs{
+			RawBid: &types.RawBid{
+				BlockNumber: backend.current.Number.Uint64() + 1,
+				ParentHash:  backend.current.Hash(),
+				GasUsed:     21000,
+				GasFee:      big.NewInt(1000),
+				BuilderFee:  big.NewInt(0),
+			},
+		}
+		if modify != nil {
+			modify(&args)
+		}
+		return args
+	}
+
+	tests := []struct {
+		reason string
+		modify func(args *types.BidArgs)
+	}{
+		{"notrunning", func(*types.BidArgs) { backend.running = false }},
+		{"notinturn", func(*types.BidArgs) { backend.inTurn = false }},
+		{"nobid", func(args *types.BidArgs) { args.RawBid = nil }},
+		{"blocknumber", func(args *types.BidArgs) { args.RawBid.BlockNumber++ }},
+		{"parent", func(args *types.BidArgs) { args.RawBid.ParentHash = common.Hash{0x01} }},
+		{"gasfee", func(args *types.BidArgs) { args.RawBid.GasFee = big.NewInt(0) }},
+		{"builderfee", func(args *types.BidArgs) { args.RawBid.BuilderFee = big.NewInt(1000) }},
+		{"paybidtx", func(args *types.BidArgs) { args.PayBidTx = hexutil.Bytes{0x01} }},
+	}
+
+	for _, tt := range tests {
+		backend.running, backend.inTurn = true, true
+		args := newBidArgs(tt.modify)
+
+		received, rejected := count("received"), count("rejected/"+tt.reason)
+		if _, err := api.SendBid(context.Background(), args); err == nil {
+			t.Fatalf("%s: expected bid to be rejected", tt.reason)
+		}
+		if have := count("received") - received; have != 1 {
+			t.Errorf("%s: received counter increased by %d, want 1", tt.reason, have)
+		}
+		if have := count("rejected/"+tt.reason) - rejected; have != 1 {
+			t.Errorf("%s: rejected counter increased by %d, want 1", tt.reason, have)
+		}
+	}
+
+	// the bid passed to the miner is counted as received only
+	backend.running, backend.inTurn = true, true
+	received := count("received")
+	if _, err := api.SendBid(context.Background(), newBidArgs(nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if have := count("received") - received; have != 1 {
+		t.Errorf("received counter increased by %d, want 1", have)
+	}
+}
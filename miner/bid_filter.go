@@ -0,0 +1,70 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultBidFilterTimeout is the max time the bid filter may take if not configured
+const defaultBidFilterTimeout = 20 * time.Millisecond
+
+// BidFilter is an external policy to accept or reject simulated bids, e.g. for compliance.
+// It is called on the bid simulation path after the reward of the bid is checked, so it
+// is given at most MevConfig.BidFilterTimeout to decide.
+type BidFilter interface {
+	// FilterBid reports whether the bid is accepted, and the reason if it is rejected.
+	// A non-nil error means no decision was made, MevConfig.BidFilterFailOpen decides then.
+	FilterBid(ctx context.Context, bid *BidRuntime) (bool, string, error)
+}
+
+// filterBid returns an error if the bid filter rejects the bid, or fails to decide and
+// the filter is configured to fail closed.
+func (b *bidSimulator) filterBid(bidRuntime *BidRuntime) error {
+	filter := b.getBidFilter()
+	if filter == nil {
+		return nil
+	}
+
+	// the simulation of all bids waits for the filter, so it must be bounded
+	timeout := b.config.BidFilterTimeout
+	if timeout <= 0 {
+		timeout = defaultBidFilterTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		accepted bool
+		reason   string
+		err      error
+	}
+
+	resCh := make(chan result, 1)
+	go func() {
+		accepted, reason, err := filter.FilterBid(ctx, bidRuntime)
+		resCh <- result{accepted, reason, err}
+	}()
+
+	var res result
+	select {
+	case res = <-resCh:
+	case <-ctx.Done():
+		res.err = errors.New("timeout")
+	case <-b.exitCh:
+		return errors.New("miner exit")
+	}
+
+	switch {
+	case res.err != nil && b.config.BidFilterFailOpen:
+		return nil
+	case res.err != nil:
+		return fmt.Errorf("bid filter failed, %v", res.err)
+	case !res.accepted:
+		return fmt.Errorf("bid rejected by filter, %s", res.reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testBidFilter struct {
+	accepted bool
+	err      error
+	delay    time.Duration
+}
+
+func (f *testBidFilter) FilterBid(ctx context.Context, _ *BidRuntime) (bool, string, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return false, "", ctx.Err()
+		}
+	}
+	return f.accepted, "denied by policy", f.err
+}
+
+func TestFilterBid(t *testing.T) {
+	bid := newTestBidRuntime(testBuilderA, 100)
+
+	tests := []struct {
+		name     string
+		filter   BidFilter
+		failOpen bool
+		valid    bool
+	}{
+		{"no filter", nil, false, true},
+		{"accept", &testBidFilter{accepted: true}, false, true},
+		{"reject", &testBidFilter{accepted: false}, false, false},
+		{"reject fail open", &testBidFilter{accepted: false}, true, false},
+		{"error fail closed", &testBidFilter{err: errors.New("unavailable")}, false, false},
+		{"error fail open", &testBidFilter{err: errors.New("unavailable")}, true, true},
+		{"timeout fail closed", &testBidFilter{accepted: true, delay: time.Second}, false, false},
+		{"timeout fail open", &testBidFilter{accepted: true, delay: time.Second}, true, true},
+	}
+
+	for _, tt := range tests {
+		b := &bidSimulator{config: &MevConfig{BidFilterTimeout: 20 * time.Millisecond, BidFilterFailOpen: tt.failOpen}}
+		b.SetBidFilter(tt.filter)
+
+		if err := b.filterBid(bid); (err == nil) != tt.valid {
+			t.Errorf("%s: unexpected result %v", tt.name, err)
+		}
+	}
+}
+
+func TestFilterBidBounded(t *testing.T) {
+	bid := newTestBidRuntime(testBuilderA, 100)
+	hanging := &testBidFilter{accepted: true, delay: time.Hour}
+
+	// no timeout configured, the default one applies
+	b := &bidSimulator{config: &MevConfig{}, exitCh: make(chan struct{})}
+	b.SetBidFilter(hanging)
+	if err := b.filterBid(bid); err == nil {
+		t.Fatal("hanging filter accepted the bid")
+	}
+
+	// the miner exits while the filter is deciding
+	b = &bidSimulator{config: &MevConfig{BidFilterTimeout: time.Hour, BidFilterFailOpen: true}, exitCh: make(chan struct{})}
+	b.SetBidFilter(hanging)
+	close(b.exitCh)
+	if err := b.filterBid(bid); err == nil {
+		t.Fatal("bid accepted after the miner exited")
+	}
+}
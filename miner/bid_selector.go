@@ -0,0 +1,102 @@
+package miner
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BidSelector is the strategy to select the bid to seal.
+// It is called for every received bid, every successfully simulated bid and every
+// in-turn block, so implementations must be deterministic and fast, and must not
+// modify the bids.
+type BidSelector interface {
+	// MayReplace reports whether the received bid, judged by its expected rewards, may
+	// replace the other bid of the same parent, which is either the bid in simulation or
+	// the best bid. Only the bids that may replace both of them are simulated.
+	MayReplace(bid, other *BidRuntime) bool
+
+	// IsBetterBid reports whether the simulated bid should replace the current best bid
+	// of the same parent.
+	IsBetterBid(bid, bestBid *BidRuntime) bool
+
+	// IsBetterThanLocal reports whether the best bid should be sealed instead of the local
+	// block, which pays localReward to the system address.
+	IsBetterThanLocal(bid *BidRuntime, localReward *big.Int) bool
+}
+
+// rewardBidSelector is the default BidSelector, it selects the bid with the highest
+// block reward, unless the preferred builder is configured and its bid is close enough.
+type rewardBidSelector struct {
+	config *MevConfig
+}
+
+// MayReplace requires both rewards to be better, unless one of the bids is the bid of
+// the preferred builder and the rewards are close enough.
+func (s *rewardBidSelector) MayReplace(bid, other *BidRuntime) bool {
+	if preferBuilder(s.config, other.bid.Builder, other.expectedBlockReward, bid.bid.Builder, bid.expectedBlockReward) {
+		return false
+	}
+
+	if bid.expectedBlockReward.Cmp(other.expectedBlockReward) > 0 &&
+		bid.expectedValidatorReward.Cmp(other.expectedValidatorReward) > 0 {
+		return true
+	}
+
+	return preferBuilder(s.config, bid.bid.Builder, bid.expectedBlockReward, other.bid.Builder, other.expectedBlockReward)
+}
+
+func (s *rewardBidSelector) IsBetterBid(bid, bestBid *BidRuntime) bool {
+	// this is the simplest strategy: best for all the delegators.
+	better := bid.packedBlockReward.Cmp(bestBid.packedBlockReward) > 0
+
+	// the preferred builder overrides the simplest strategy when the rewards are close
+	switch {
+	case !better && preferBuilder(s.config, bid.bid.Builder, bid.packedBlockReward, bestBid.bid.Builder, bestBid.packedBlockReward):
+		log.Info("BidSimulator: preferred builder replaces best bid", "blockNumber", bid.bid.BlockNumber,
+			"builder", bid.bid.Builder, "reward", bid.packedBlockReward,
+			"bestBuilder", bestBid.bid.Builder, "bestReward", bestBid.packedBlockReward)
+		return true
+
+	case better && preferBuilder(s.config, bestBid.bid.Builder, bestBid.packedBlockReward, bid.bid.Builder, bid.packedBlockReward):
+		log.Info("BidSimulator: preferred builder keeps best bid", "blockNumber", bid.bid.BlockNumber,
+			"builder", bid.bid.Builder, "reward", bid.packedBlockReward,
+			"bestBuilder", bestBid.bid.Builder, "bestReward", bestBid.packedBlockReward)
+		return false
+	}
+
+	return better
+}
+
+func (s *rewardBidSelector) IsBetterThanLocal(bid *BidRuntime, localReward *big.Int) bool {
+	if bid.packedBlockReward.Cmp(localReward) <= 0 {
+		return false
+	}
+
+	// localValidatorReward is the reward for the validator self by the local block.
+	localValidatorReward := new(big.Int).Mul(localReward, big.NewInt(int64(s.config.ValidatorCommission)))
+	localValidatorReward.Div(localValidatorReward, big.NewInt(10000))
+
+	// blockReward(benefits delegators) and validatorReward(benefits the validator) are both optimal
+	return localValidatorReward.Cmp(bid.packedValidatorReward) < 0
+}
+
+// preferBuilder reports whether a bid from builder should win over a bid from otherBuilder,
+// that is builder is the preferred one and its reward is within the preferred band of otherReward.
+func preferBuilder(config *MevConfig, builder common.Address, reward *big.Int, otherBuilder common.Address, otherReward *big.Int) bool {
+	preferred := config.PreferredBuilder
+	if preferred == (common.Address{}) || builder != preferred || otherBuilder == preferred {
+		return false
+	}
+
+	if config.PreferredBuilderBand >= 10000 {
+		return true
+	}
+
+	// reward >= otherReward * (1 - band)
+	floor := new(big.Int).Mul(otherReward, big.NewInt(int64(10000-config.PreferredBuilderBand)))
+	floor.Div(floor, big.NewInt(10000))
+
+	return reward.Cmp(floor) >= 0
+}
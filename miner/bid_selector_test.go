@@ -0,0 +1,131 @@
+package miner
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestRewardBidSelector(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred common.Address
+		band      uint64
+		bid       *BidRuntime
+		best      *BidRuntime
+		want      bool
+	}{
+		{"higher reward", common.Address{}, 0, newTestBidRuntime(testBuilderA, 101), newTestBidRuntime(testBuilderB, 100), true},
+		{"equal reward", common.Address{}, 0, newTestBidRuntime(testBuilderA, 100), newTestBidRuntime(testBuilderB, 100), false},
+		{"lower reward", common.Address{}, 0, newTestBidRuntime(testBuilderA, 99), newTestBidRuntime(testBuilderB, 100), false},
+		{"preferred within band", testBuilderA, 500, newTestBidRuntime(testBuilderA, 95), newTestBidRuntime(testBuilderB, 100), true},
+		{"preferred outside band", testBuilderA, 500, newTestBidRuntime(testBuilderA, 94), newTestBidRuntime(testBuilderB, 100), false},
+		{"preferred best within band", testBuilderA, 500, newTestBidRuntime(testBuilderB, 105), newTestBidRuntime(testBuilderA, 100), false},
+		{"preferred best outside band", testBuilderA, 500, newTestBidRuntime(testBuilderB, 110), newTestBidRuntime(testBuilderA, 100), true},
+		{"preferred against itself", testBuilderA, 500, newTestBidRuntime(testBuilderA, 99), newTestBidRuntime(testBuilderA, 100), false},
+	}
+
+	for _, tt := range tests {
+		s := &rewardBidSelector{config: &MevConfig{PreferredBuilder: tt.preferred, PreferredBuilderBand: tt.band}}
+		if got := s.IsBetterBid(tt.bid, tt.best); got != tt.want {
+			t.Errorf("%s: IsBetterBid = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRewardBidSelectorLocal(t *testing.T) {
+	// the local block reward is 1000, and the local validator reward is 10
+	tests := []struct {
+		blockReward     int64
+		validatorReward int64
+		want            bool
+	}{
+		{1001, 11, true},
+		{1000, 100, false},
+		{1001, 10, false},
+	}
+
+	s := &rewardBidSelector{config: &MevConfig{ValidatorCommission: 100}}
+	for i, tt := range tests {
+		bid := newTestBidRuntime(testBuilderA, tt.blockReward)
+		bid.packedValidatorReward = big.NewInt(tt.validatorReward)
+		if got := s.IsBetterThanLocal(bid, big.NewInt(1000)); got != tt.want {
+			t.Errorf("test %d: IsBetterThanLocal = %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+// lowestRewardSelector is a selector preferring the bid with the lower reward.
+type lowestRewardSelector struct{}
+
+func (lowestRewardSelector) MayReplace(bid, other *BidRuntime) bool {
+	return bid.ExpectedBlockReward().Cmp(other.ExpectedBlockReward()) < 0
+}
+
+func (lowestRewardSelector) IsBetterBid(bid, bestBid *BidRuntime) bool {
+	return bid.PackedBlockReward().Cmp(bestBid.PackedBlockReward()) < 0
+}
+
+func (lowestRewardSelector) IsBetterThanLocal(*BidRuntime, *big.Int) bool {
+	return true
+}
+
+func TestSetBidSelector(t *testing.T) {
+	b, backend := newTestBidSimulator(t, &MevConfig{ValidatorCommission: 100})
+
+	// the genesis block is old, leave enough time to simulate the bids on top of it
+	chainConfig := *b.chainConfig
+	chainConfig.Parlia = &params.ParliaConfig{Period: uint64(time.Now().Unix()) + 3600}
+	b.chainConfig = &chainConfig
+	b.chainHeadSub = backend.chain.SubscribeChainHeadEvent(b.chainHeadCh)
+	go b.mainLoop()
+	go b.newBidLoop()
+
+	ch := make(chan NewBestBidEvent, 10)
+	sub := b.newBestBidFeed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	send := func(builder common.Address, reward int64) {
+		bid := newTestSimBid(backend, builder, params.TxGas, reward).bid
+		// the test bids have no raw bid so they share the same hash, only set up the pending maps
+		_ = b.CheckPending(bid.BlockNumber, builder, bid.Hash())
+		if err := b.sendBid(context.Background(), bid); err != nil {
+			t.Fatalf("failed to send bid: %v", err)
+		}
+	}
+	// the packed reward includes the gas fee of the bid tx, which is the same for all bids
+	var fee *big.Int
+	expect := func(builder common.Address, reward int64) {
+		select {
+		case ev := <-ch:
+			if fee == nil {
+				fee = new(big.Int).Sub(ev.Reward, big.NewInt(reward))
+			}
+			if ev.Builder != builder || ev.Reward.Cmp(new(big.Int).Add(fee, big.NewInt(reward))) != 0 {
+				t.Fatalf("unexpected best bid from %v with reward %v", ev.Builder, ev.Reward)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no best bid from %v with reward %d", builder, reward)
+		}
+	}
+
+	// the default selector keeps the highest reward
+	send(testBuilderA, 2000)
+	expect(testBuilderA, 2000)
+	send(testBuilderB, 1000)
+	send(testBuilderA, 3000)
+	expect(testBuilderA, 3000)
+
+	// the custom selector decides which bids are simulated as well
+	b.SetBidSelector(lowestRewardSelector{})
+	send(testBuilderB, 999)
+	expect(testBuilderB, 999)
+
+	b.SetBidSelector(nil)
+	send(testBuilderA, 1500)
+	expect(testBuilderA, 1500)
+}
@@ -34,10 +34,20 @@ const (
 	leftOverTimeRate = 11
 	// leftOverTimeScale is the scale of left over time to simulate a bid
 	leftOverTimeScale = 10
+
+	// defaultBidQueueTimeout is the max time a bid waits to be queued if not configured
+	defaultBidQueueTimeout = 1 * time.Second
+
+	// newBestBidChanSize is the size of the buffer of new best bid events to dispatch
+	newBestBidChanSize = 64
 )
 
 var (
 	bidSimTimer = metrics.NewRegisteredTimer("bid/sim/duration", nil)
+	// bidRewardHist is the distribution of validator rewards of valid bids, in gwei
+	bidRewardHist = metrics.NewRegisteredHistogram("bid/sim/reward", nil, metrics.NewExpDecaySample(1028, 0.015))
+	// newBestBidDropCounter counts the new best bid events dropped due to slow subscribers
+	newBestBidDropCounter = metrics.NewRegisteredCounter("bid/newbest/dropped", nil)
 )
 
 var (
@@ -66,6 +76,17 @@ type WorkPreparer interface {
 	etherbase() common.Address
 }
 
+// NewBestBidEvent is posted when a simulated bid becomes the best bid of its parent.
+type NewBestBidEvent struct {
+	Builder     common.Address
+	BlockNumber uint64
+	ParentHash  common.Hash
+	BidHash     common.Hash
+	Reward      *big.Int // packed block reward
+	TxCount     int
+	Time        time.Time
+}
+
 // simBidReq is the request for simulating a bid
 type simBidReq struct {
 	bid         *BidRuntime
@@ -86,6 +107,8 @@ type bidSimulator struct {
 
 	bidReceiving atomic.Bool // controlled by config and eth.AdminAPI
 
+	builderFeeCeil atomic.Pointer[big.Int] // controlled by config and eth.AdminAPI, nil means no ceil
+
 	chainHeadCh  chan core.ChainHeadEvent
 	chainHeadSub event.Subscription
 
@@ -95,6 +118,16 @@ type bidSimulator struct {
 	buildersMu sync.RWMutex
 	builders   map[common.Address]*builderclient.Client
 
+	deniedMu        sync.RWMutex
+	deniedTxTargets map[common.Address]struct{}
+
+	receivingMu   sync.Mutex
+	receiving     map[common.Address]int // builder -> number of bids being received
+	receivingBids int
+
+	lastBidMu sync.Mutex
+	lastBid   map[common.Address]time.Time // builder -> time of the last bid
+
 	// channels
 	simBidCh chan *simBidReq
 	newBidCh chan *types.Bid
@@ -107,6 +140,13 @@ type bidSimulator struct {
 
 	simBidMu      sync.RWMutex
 	simulatingBid map[common.Hash]*BidRuntime // prevBlockHash -> bidRuntime, in the process of simulation
+
+	newBestBidCh   chan NewBestBidEvent
+	newBestBidFeed event.Feed
+
+	selectorMu sync.RWMutex // The lock used to protect the selector and the filter
+	selector   BidSelector
+	filter     BidFilter
 }
 
 func newBidSimulator(
@@ -117,19 +157,37 @@ func newBidSimulator(
 	workPreparer WorkPreparer,
 ) *bidSimulator {
 	b := &bidSimulator{
-		config:        config,
-		delayLeftOver: delayLeftOver,
-		chainConfig:   chainConfig,
-		chain:         chain,
-		workPreparer:  workPreparer,
-		exitCh:        make(chan struct{}),
-		chainHeadCh:   make(chan core.ChainHeadEvent, chainHeadChanSize),
-		builders:      make(map[common.Address]*builderclient.Client),
-		simBidCh:      make(chan *simBidReq),
-		newBidCh:      make(chan *types.Bid, 100),
-		pending:       make(map[uint64]map[common.Address]map[common.Hash]struct{}),
-		bestBid:       make(map[common.Hash]*BidRuntime),
-		simulatingBid: make(map[common.Hash]*BidRuntime),
+		config:          config,
+		delayLeftOver:   delayLeftOver,
+		chainConfig:     chainConfig,
+		chain:           chain,
+		workPreparer:    workPreparer,
+		exitCh:          make(chan struct{}),
+		chainHeadCh:     make(chan core.ChainHeadEvent, chainHeadChanSize),
+		builders:        make(map[common.Address]*builderclient.Client),
+		deniedTxTargets: make(map[common.Address]struct{}),
+		receiving:       make(map[common.Address]int),
+		lastBid:         make(map[common.Address]time.Time),
+		simBidCh:        make(chan *simBidReq),
+		newBidCh:        make(chan *types.Bid, 100),
+		newBestBidCh:    make(chan NewBestBidEvent, newBestBidChanSize),
+		pending:         make(map[uint64]map[common.Address]map[common.Hash]struct{}),
+		bestBid:         make(map[common.Hash]*BidRuntime),
+		simulatingBid:   make(map[common.Hash]*BidRuntime),
+	}
+
+	if config.BuilderFeeCeil != "" {
+		if builderFeeCeil, ok := new(big.Int).SetString(config.BuilderFeeCeil, 10); ok {
+			b.builderFeeCeil.Store(builderFeeCeil)
+		} else {
+			// do not let a typo accept any builder fee
+			log.Error("BidSimulator: failed to parse builder fee ceil, rejecting any builder fee", "BuilderFeeCeil", config.BuilderFeeCeil)
+			b.builderFeeCeil.Store(big.NewInt(0))
+		}
+	}
+
+	for _, target := range config.DeniedTxTargets {
+		b.deniedTxTargets[target] = struct{}{}
 	}
 
 	b.chainHeadSub = chain.SubscribeChainHeadEvent(b.chainHeadCh)
@@ -146,6 +204,7 @@ func newBidSimulator(
 	go b.clearLoop()
 	go b.mainLoop()
 	go b.newBidLoop()
+	go b.newBestBidLoop()
 
 	return b
 }
@@ -189,6 +248,19 @@ func (b *bidSimulator) receivingBid() bool {
 	return b.bidReceiving.Load()
 }
 
+// expectingBids reports whether bids are expected, that is bids are being received and
+// at least one builder is registered.
+func (b *bidSimulator) expectingBids() bool {
+	if !b.isRunning() || !b.receivingBid() {
+		return false
+	}
+
+	b.buildersMu.RLock()
+	defer b.buildersMu.RUnlock()
+
+	return len(b.builders) > 0
+}
+
 func (b *bidSimulator) startReceivingBid() {
 	b.dialSentryAndBuilders()
 	b.bidReceiving.Store(true)
@@ -202,6 +274,11 @@ func (b *bidSimulator) AddBuilder(builder common.Address, url string) error {
 	b.buildersMu.Lock()
 	defer b.buildersMu.Unlock()
 
+	if _, ok := b.builders[builder]; !ok && b.config.MaxBuilders > 0 && len(b.builders) >= b.config.MaxBuilders {
+		log.Error("BidSimulator: too many builders", "builder", builder, "max", b.config.MaxBuilders)
+		return fmt.Errorf("too many builders, max %d", b.config.MaxBuilders)
+	}
+
 	if b.sentryCli != nil {
 		b.builders[builder] = b.sentryCli
 	} else {
@@ -229,6 +306,16 @@ func (b *bidSimulator) RemoveBuilder(builder common.Address) error {
 
 	delete(b.builders, builder)
 
+	// the best bids of a removed builder are invalidated, the worker may still be using
+	// their env so leave them to the GC rather than discarding them
+	b.bestBidMu.Lock()
+	for k, v := range b.bestBid {
+		if v.bid.Builder == builder {
+			delete(b.bestBid, k)
+		}
+	}
+	b.bestBidMu.Unlock()
+
 	return nil
 }
 
@@ -241,6 +328,183 @@ func (b *bidSimulator) ExistBuilder(builder common.Address) bool {
 	return ok
 }
 
+func (b *bidSimulator) AddDeniedTxTarget(target common.Address) {
+	b.deniedMu.Lock()
+	defer b.deniedMu.Unlock()
+
+	b.deniedTxTargets[target] = struct{}{}
+}
+
+func (b *bidSimulator) RemoveDeniedTxTarget(target common.Address) {
+	b.deniedMu.Lock()
+	defer b.deniedMu.Unlock()
+
+	delete(b.deniedTxTargets, target)
+}
+
+// SetBuilderFeeCeil sets the maximum builder fee of a bid, nil means no ceil.
+func (b *bidSimulator) SetBuilderFeeCeil(ceil *big.Int) {
+	b.builderFeeCeil.Store(ceil)
+}
+
+// BuilderFeeCeil returns the maximum builder fee of a bid, nil means no ceil.
+func (b *bidSimulator) BuilderFeeCeil() *big.Int {
+	return b.builderFeeCeil.Load()
+}
+
+// CheckBuilderFee returns an error if the builder fee of the bid exceeds the ceil.
+func (b *bidSimulator) CheckBuilderFee(bid *types.Bid) error {
+	ceil := b.BuilderFeeCeil()
+	if ceil == nil || bid.BuilderFee.Cmp(ceil) <= 0 {
+		return nil
+	}
+
+	return types.NewInvalidBidError(fmt.Sprintf("builder fee %v exceeds the ceil %v", bid.BuilderFee, ceil))
+}
+
+// CheckLateBid returns an error if the bid arrives within LateBidWindow before its
+// deadline and its reward does not exceed the best bid by more than LateBidMargin,
+// simulating such a bid is not worth the risk to the sealing time.
+func (b *bidSimulator) CheckLateBid(bid *types.Bid, timeLeft time.Duration) error {
+	if b.config.LateBidWindow == 0 || timeLeft >= b.config.LateBidWindow {
+		return nil
+	}
+
+	bestBid := b.GetBestBid(bid.ParentHash)
+	if bestBid == nil {
+		return nil
+	}
+
+	threshold := new(big.Int).Mul(bestBid.packedBlockReward, big.NewInt(int64(10000+b.config.LateBidMargin)))
+	threshold.Div(threshold, big.NewInt(10000))
+
+	if bid.GasFee.Cmp(threshold) > 0 {
+		return nil
+	}
+
+	return types.NewInvalidBidError(fmt.Sprintf("late bid reward %v does not exceed the best %v by the margin",
+		bid.GasFee, bestBid.packedBlockReward))
+}
+
+// CheckTxTargets returns an error if any tx of the bid is sent to a denied address.
+func (b *bidSimulator) CheckTxTargets(bid *types.Bid) error {
+	b.deniedMu.RLock()
+	defer b.deniedMu.RUnlock()
+
+	if len(b.deniedTxTargets) == 0 {
+		return nil
+	}
+
+	for _, tx := range bid.Txs {
+		if to := tx.To(); to != nil {
+			if _, ok := b.deniedTxTargets[*to]; ok {
+				return types.NewInvalidBidError(fmt.Sprintf("tx %v is sent to denied address %v", tx.Hash(), *to))
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckBidInterval returns an error if the last bid of the builder is more recent than its
+// configured minimum interval, otherwise the bid is recorded at once so that concurrent bids
+// of the builder are rejected. The returned function must be called to give the interval
+// back if the bid is rejected later.
+func (b *bidSimulator) CheckBidInterval(builder common.Address) (func(), error) {
+	interval := b.builderConfig(builder).MinBidInterval
+	if interval <= 0 {
+		return func() {}, nil
+	}
+
+	b.lastBidMu.Lock()
+	defer b.lastBidMu.Unlock()
+
+	last, ok := b.lastBid[builder]
+	if ok && time.Since(last) < interval {
+		return nil, types.NewInvalidBidError(fmt.Sprintf("too frequent bids, min interval %v", interval))
+	}
+
+	now := time.Now()
+	b.lastBid[builder] = now
+
+	return func() {
+		b.lastBidMu.Lock()
+		defer b.lastBidMu.Unlock()
+
+		// a later bid may have been recorded once the interval passed, keep it
+		if !b.lastBid[builder].Equal(now) {
+			return
+		}
+		if ok {
+			b.lastBid[builder] = last
+		} else {
+			delete(b.lastBid, builder)
+		}
+	}, nil
+}
+
+// acquireBidSlot reserves a slot for receiving a bid from the builder, the returned
+// function must be called to release the slot once the bid is received.
+func (b *bidSimulator) acquireBidSlot(builder common.Address) (func(), error) {
+	b.receivingMu.Lock()
+	defer b.receivingMu.Unlock()
+
+	if b.config.MaxInFlightBids > 0 && b.receivingBids >= b.config.MaxInFlightBids {
+		return nil, types.ErrMevBusy
+	}
+
+	if b.config.MaxInFlightPerBuilder > 0 && b.receiving[builder] >= b.config.MaxInFlightPerBuilder {
+		return nil, types.NewInvalidBidError("too many concurrent bids from the builder")
+	}
+
+	b.receivingBids++
+	b.receiving[builder]++
+
+	return func() {
+		b.receivingMu.Lock()
+		defer b.receivingMu.Unlock()
+
+		b.receivingBids--
+		if b.receiving[builder]--; b.receiving[builder] == 0 {
+			delete(b.receiving, builder)
+		}
+	}, nil
+}
+
+// SetBidSelector replaces the strategy to select the best bid, nil restores the default one.
+func (b *bidSimulator) SetBidSelector(selector BidSelector) {
+	b.selectorMu.Lock()
+	defer b.selectorMu.Unlock()
+
+	b.selector = selector
+}
+
+func (b *bidSimulator) getBidSelector() BidSelector {
+	b.selectorMu.RLock()
+	defer b.selectorMu.RUnlock()
+
+	if b.selector == nil {
+		return &rewardBidSelector{config: b.config}
+	}
+
+	return b.selector
+}
+
+// SetBidFilter sets the policy to accept or reject simulated bids, nil removes it.
+func (b *bidSimulator) SetBidFilter(filter BidFilter) {
+	b.selectorMu.Lock()
+	defer b.selectorMu.Unlock()
+
+	b.filter = filter
+}
+
+func (b *bidSimulator) getBidFilter() BidFilter {
+	b.selectorMu.RLock()
+	defer b.selectorMu.RUnlock()
+
+	return b.filter
+}
+
 func (b *bidSimulator) SetBestBid(prevBlockHash common.Hash, bid *BidRuntime) {
 	b.bestBidMu.Lock()
 	defer b.bestBidMu.Unlock()
@@ -356,34 +620,32 @@ func (b *bidSimulator) newBidLoop() {
 
 			// TODO(renee-) opt bid comparation
 
-			simulatingBid := b.GetSimulatingBid(newBid.ParentHash)
-			// simulatingBid is nil means there is no bid in simulation
-			if simulatingBid == nil {
-				// bestBid is nil means bid is the first bid
-				bestBid := b.GetBestBid(newBid.ParentHash)
-				if bestBid == nil {
-					commit(commitInterruptBetterBid, bidRuntime)
-					continue
-				}
-
-				// if bestBid is not nil, check if newBid is better than bestBid
-				if bidRuntime.expectedBlockReward.Cmp(bestBid.expectedBlockReward) > 0 &&
-					bidRuntime.expectedValidatorReward.Cmp(bestBid.expectedValidatorReward) > 0 {
-					// if both reward are better than last simulating newBid, commit for simulation
-					commit(commitInterruptBetterBid, bidRuntime)
-					continue
-				}
-
-				continue
-			}
-
-			// simulatingBid must be better than bestBid, if newBid is better than simulatingBid, commit for simulation
-			if bidRuntime.expectedBlockReward.Cmp(simulatingBid.expectedBlockReward) > 0 &&
-				bidRuntime.expectedValidatorReward.Cmp(simulatingBid.expectedValidatorReward) > 0 {
-				// if both reward are better than last simulating newBid, commit for simulation
+			if b.shouldSimulate(bidRuntime) {
 				commit(commitInterruptBetterBid, bidRuntime)
-				continue
 			}
+		case <-b.exitCh:
+			return
+		}
+	}
+}
+
+// postNewBestBid queues the event for newBestBidLoop, the event is dropped if the queue
+// is full, so slow subscribers never block the simulation.
+func (b *bidSimulator) postNewBestBid(ev NewBestBidEvent) {
+	select {
+	case b.newBestBidCh <- ev:
+	default:
+		newBestBidDropCounter.Inc(1)
+		log.Debug("BidSimulator: new best bid event dropped", "blockNumber", ev.BlockNumber, "builder", ev.Builder)
+	}
+}
+
+// newBestBidLoop dispatches the new best bid events to the subscribers in order.
+func (b *bidSimulator) newBestBidLoop() {
+	for {
+		select {
+		case ev := <-b.newBestBidCh:
+			b.newBestBidFeed.Send(ev)
 
 		case <-b.exitCh:
 			return
@@ -391,6 +653,26 @@ func (b *bidSimulator) newBidLoop() {
 	}
 }
 
+// shouldSimulate reports whether the new bid is worth simulating, that is it may replace
+// both the bid in simulation and the best bid of the same parent. The bid in simulation is
+// usually better than the best bid, but not if it is the bid of the preferred builder.
+func (b *bidSimulator) shouldSimulate(bidRuntime *BidRuntime) bool {
+	var (
+		parentHash = bidRuntime.bid.ParentHash
+		selector   = b.getBidSelector()
+	)
+
+	if simulatingBid := b.GetSimulatingBid(parentHash); simulatingBid != nil && !selector.MayReplace(bidRuntime, simulatingBid) {
+		return false
+	}
+
+	if bestBid := b.GetBestBid(parentHash); bestBid != nil && !selector.MayReplace(bidRuntime, bestBid) {
+		return false
+	}
+
+	return true
+}
+
 func (b *bidSimulator) bidMustBefore(parentHash common.Hash) time.Time {
 	parentHeader := b.chain.GetHeaderByHash(parentHash)
 	return bidutil.BidMustBefore(parentHeader, b.chainConfig.Parlia.Period, b.delayLeftOver)
@@ -445,8 +727,20 @@ func (b *bidSimulator) clearLoop() {
 
 // sendBid checks if the bid is already exists or if the builder sends too many bids,
 // if yes, return error, if not, add bid into newBid chan waiting for judge profit.
-func (b *bidSimulator) sendBid(_ context.Context, bid *types.Bid) error {
-	timer := time.NewTimer(1 * time.Second)
+// If the bid can not be queued in time, ErrMevBusy is returned rather than waiting
+// until the context is done.
+func (b *bidSimulator) sendBid(ctx context.Context, bid *types.Bid) error {
+	// bids are dropped by newBidLoop when not running, tell the builder at once
+	if !b.isRunning() {
+		return types.ErrMevNotRunning
+	}
+
+	timeout := b.config.BidQueueTimeout
+	if timeout <= 0 {
+		timeout = defaultBidQueueTimeout
+	}
+
+	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 	select {
 	case b.newBidCh <- bid:
@@ -454,6 +748,10 @@ func (b *bidSimulator) sendBid(_ context.Context, bid *types.Bid) error {
 		return nil
 	case <-timer.C:
 		return types.ErrMevBusy
+	case <-ctx.Done():
+		return types.ErrMevBusy
+	case <-b.exitCh:
+		return types.ErrMevNotRunning
 	}
 }
 
@@ -533,6 +831,16 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 
 		if success {
 			bidRuntime.duration = time.Since(simStart)
+
+			b.postNewBestBid(NewBestBidEvent{
+				Builder:     builder,
+				BlockNumber: blockNumber,
+				ParentHash:  parentHash,
+				BidHash:     bidRuntime.bid.Hash(),
+				Reward:      new(big.Int).Set(bidRuntime.packedBlockReward),
+				TxCount:     len(bidRuntime.bid.Txs),
+				Time:        time.Now(),
+			})
 		}
 
 		b.RemoveSimulatingBid(parentHash)
@@ -548,6 +856,12 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		return
 	}
 
+	// the zero deadline means no limit
+	var simDeadline time.Time
+	if maxSimDuration := b.builderConfig(builder).MaxSimDuration; maxSimDuration > 0 {
+		simDeadline = start.Add(maxSimDuration)
+	}
+
 	gasLimit := bidRuntime.env.header.GasLimit
 	if bidRuntime.env.gasPool == nil {
 		bidRuntime.env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -572,6 +886,11 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		default:
 		}
 
+		if !simDeadline.IsZero() && time.Now().After(simDeadline) {
+			err = errors.New("simulation abort due to timeout")
+			return
+		}
+
 		// Start executing the transaction
 		bidRuntime.env.state.SetTxContext(tx.Hash(), bidRuntime.env.tcount)
 
@@ -585,6 +904,12 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		bidRuntime.env.tcount++
 	}
 
+	// gasUsed of the bid is trusted to filter the bid before simulation, so it must not be understated
+	if !bidRuntime.validGasUsed(b.config.GasUsedTolerance) {
+		err = fmt.Errorf("gas used understated, claimed %d, simulated %d", bidRuntime.bid.GasUsed, bidRuntime.env.header.GasUsed)
+		return
+	}
+
 	bidRuntime.packReward(b.config.ValidatorCommission)
 
 	// return if bid is invalid, reportIssue issue to mev-sentry/builder if simulation is fully done
@@ -593,6 +918,12 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		return
 	}
 
+	bidRewardHist.Update(new(big.Int).Div(bidRuntime.packedValidatorReward, big.NewInt(params.GWei)).Int64())
+
+	if err = b.filterBid(bidRuntime); err != nil {
+		return
+	}
+
 	bestBid := b.GetBestBid(parentHash)
 
 	if bestBid == nil {
@@ -601,14 +932,25 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		return
 	}
 
-	// this is the simplest strategy: best for all the delegators.
-	if bidRuntime.packedBlockReward.Cmp(bestBid.packedBlockReward) > 0 {
+	if b.getBidSelector().IsBetterBid(bidRuntime, bestBid) {
 		b.SetBestBid(bidRuntime.bid.ParentHash, bidRuntime)
 		success = true
 		return
 	}
 }
 
+// builderConfig returns the configuration of the builder, builders added at runtime
+// have no configuration and the zero value is returned.
+func (b *bidSimulator) builderConfig(builder common.Address) BuilderConfig {
+	for _, v := range b.config.Builders {
+		if v.Address == builder {
+			return v
+		}
+	}
+
+	return BuilderConfig{Address: builder}
+}
+
 // reportIssue reports the issue to the mev-sentry
 func (b *bidSimulator) reportIssue(bidRuntime *BidRuntime, err error) {
 	metrics.GetOrRegisterCounter(fmt.Sprintf("bid/err/%v", bidRuntime.bid.Builder), nil).Inc(1)
@@ -637,11 +979,49 @@ type BidRuntime struct {
 	duration time.Duration
 }
 
+// Bid returns the bid being simulated.
+func (r *BidRuntime) Bid() *types.Bid {
+	return r.bid
+}
+
+// ExpectedBlockReward returns the block reward the bid claims before simulation.
+func (r *BidRuntime) ExpectedBlockReward() *big.Int {
+	return r.expectedBlockReward
+}
+
+// ExpectedValidatorReward returns the validator reward the bid claims before simulation.
+func (r *BidRuntime) ExpectedValidatorReward() *big.Int {
+	return r.expectedValidatorReward
+}
+
+// PackedBlockReward returns the block reward of the simulated bid.
+func (r *BidRuntime) PackedBlockReward() *big.Int {
+	return r.packedBlockReward
+}
+
+// PackedValidatorReward returns the validator reward of the simulated bid.
+func (r *BidRuntime) PackedValidatorReward() *big.Int {
+	return r.packedValidatorReward
+}
+
 func (r *BidRuntime) validReward() bool {
 	return r.packedBlockReward.Cmp(r.expectedBlockReward) >= 0 &&
 		r.packedValidatorReward.Cmp(r.expectedValidatorReward) >= 0
 }
 
+// validGasUsed checks whether the claimed gasUsed of the bid is understated by no more than
+// tolerance of the simulated one, 100 means 1%. Overstating gasUsed only makes the bid less
+// likely to fit in the block, so it is allowed.
+func (r *BidRuntime) validGasUsed(tolerance uint64) bool {
+	claimed, simulated := r.bid.GasUsed, r.env.header.GasUsed
+	if claimed >= simulated {
+		return true
+	}
+
+	return new(big.Int).Mul(new(big.Int).SetUint64(simulated-claimed), big.NewInt(10000)).Cmp(
+		new(big.Int).Mul(new(big.Int).SetUint64(simulated), new(big.Int).SetUint64(tolerance))) <= 0
+}
+
 // packReward calculates packedBlockReward and packedValidatorReward
 func (r *BidRuntime) packReward(validatorCommission uint64) {
 	r.packedBlockReward = r.env.state.GetBalance(consensus.SystemAddress).ToBig()
@@ -0,0 +1,542 @@
+package miner
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner/builderclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	testBuilderA = common.HexToAddress("0x000000000000000000000000000000000000000a")
+	testBuilderB = common.HexToAddress("0x000000000000000000000000000000000000000b")
+)
+
+func newTestBidRuntime(builder common.Address, reward int64) *BidRuntime {
+	return &BidRuntime{
+		bid: &types.Bid{
+			Builder:     builder,
+			BlockNumber: 1,
+			BuilderFee:  big.NewInt(0),
+		},
+		expectedBlockReward:     big.NewInt(reward),
+		expectedValidatorReward: big.NewInt(0),
+		packedBlockReward:       big.NewInt(reward),
+		packedValidatorReward:   big.NewInt(0),
+	}
+}
+
+// newTestBidSimulator creates a running bid simulator on top of a clique test chain.
+func newTestBidSimulator(t *testing.T, config *MevConfig) (*bidSimulator, *testWorkerBackend) {
+	var (
+		db          = rawdb.NewMemoryDatabase()
+		chainConfig = *params.AllCliqueProtocolChanges
+	)
+	chainConfig.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := clique.New(chainConfig.Clique, db)
+
+	w, backend := newTestWorker(t, &chainConfig, engine, db, 0)
+	t.Cleanup(w.close)
+
+	b := &bidSimulator{
+		config:          config,
+		chain:           backend.chain,
+		chainConfig:     &chainConfig,
+		workPreparer:    w,
+		exitCh:          make(chan struct{}),
+		chainHeadCh:     make(chan core.ChainHeadEvent, chainHeadChanSize),
+		builders:        make(map[common.Address]*builderclient.Client),
+		deniedTxTargets: make(map[common.Address]struct{}),
+		receiving:       make(map[common.Address]int),
+		lastBid:         make(map[common.Address]time.Time),
+		simBidCh:        make(chan *simBidReq),
+		newBidCh:        make(chan *types.Bid, 100),
+		newBestBidCh:    make(chan NewBestBidEvent, newBestBidChanSize),
+		pending:         make(map[uint64]map[common.Address]map[common.Hash]struct{}),
+		bestBid:         make(map[common.Hash]*BidRuntime),
+		simulatingBid:   make(map[common.Hash]*BidRuntime),
+	}
+	b.running.Store(true)
+	b.bidReceiving.Store(true)
+
+	go b.newBestBidLoop()
+	t.Cleanup(func() {
+		select {
+		case <-b.exitCh:
+		default:
+			close(b.exitCh)
+		}
+	})
+
+	return b, backend
+}
+
+// newTestSimBid creates a bid on top of the chain head paying reward to the system address.
+func newTestSimBid(backend *testWorkerBackend, builder common.Address, gasUsed uint64, reward int64) *BidRuntime {
+	var (
+		head   = backend.chain.CurrentBlock()
+		signer = types.LatestSigner(backend.chain.Config())
+		to     = consensus.SystemAddress
+	)
+	tx := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce:    backend.txPool.Nonce(testBankAddress),
+		To:       &to,
+		Value:    big.NewInt(reward),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(10 * params.InitialBaseFee),
+	})
+
+	return &BidRuntime{
+		bid: &types.Bid{
+			Builder:     builder,
+			BlockNumber: head.Number.Uint64() + 1,
+			ParentHash:  head.Hash(),
+			Txs:         types.Transactions{tx},
+			GasUsed:     gasUsed,
+			GasFee:      big.NewInt(reward),
+			BuilderFee:  big.NewInt(0),
+		},
+		expectedBlockReward:     big.NewInt(reward),
+		expectedValidatorReward: big.NewInt(0),
+		packedBlockReward:       big.NewInt(0),
+		packedValidatorReward:   big.NewInt(0),
+	}
+}
+
+func TestSimBidGasUsed(t *testing.T) {
+	tests := []struct {
+		name    string
+		gasUsed uint64
+		valid   bool
+	}{
+		{"exact", params.TxGas, true},
+		{"slightly understated", params.TxGas - params.TxGas/100, true},
+		{"slightly overstated", params.TxGas + params.TxGas/100, true},
+		{"greatly understated", params.TxGas / 2, false},
+		{"greatly overstated", params.TxGas * 2, true},
+	}
+
+	for _, tt := range tests {
+		b, backend := newTestBidSimulator(t, &MevConfig{ValidatorCommission: 100, GasUsedTolerance: 500})
+
+		bidRuntime := newTestSimBid(backend, testBuilderA, tt.gasUsed, 1000)
+		b.simBid(make(chan int32, 1), bidRuntime)
+
+		if got := b.GetBestBid(bidRuntime.bid.ParentHash) != nil; got != tt.valid {
+			t.Errorf("%s: bid accepted = %v, want %v", tt.name, got, tt.valid)
+		}
+	}
+}
+
+func TestCheckTxTargets(t *testing.T) {
+	b, backend := newTestBidSimulator(t, &MevConfig{})
+	bid := newTestSimBid(backend, testBuilderA, params.TxGas, 1000).bid
+
+	if err := b.CheckTxTargets(bid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.AddDeniedTxTarget(testUserAddress)
+	if err := b.CheckTxTargets(bid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.AddDeniedTxTarget(consensus.SystemAddress)
+	if err := b.CheckTxTargets(bid); err == nil {
+		t.Fatal("expected error for tx to denied address")
+	}
+
+	b.RemoveDeniedTxTarget(consensus.SystemAddress)
+	if err := b.CheckTxTargets(bid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendBidQueueTimeout(t *testing.T) {
+	// nobody reads newBidCh, so the bid can never be queued
+	b := &bidSimulator{
+		config:   &MevConfig{BidQueueTimeout: 50 * time.Millisecond},
+		exitCh:   make(chan struct{}),
+		newBidCh: make(chan *types.Bid),
+	}
+	b.start()
+	bid := newTestBidRuntime(testBuilderA, 100).bid
+
+	start := time.Now()
+	if err := b.sendBid(context.Background(), bid); !errors.Is(err, types.ErrMevBusy) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("bid rejected too early: %v", elapsed)
+	}
+
+	// the deadline of the context is earlier than the queue timeout
+	b.config.BidQueueTimeout = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start = time.Now()
+	if err := b.sendBid(ctx, bid); !errors.Is(err, types.ErrMevBusy) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("bid rejected too late: %v", elapsed)
+	}
+}
+
+func TestSendBidNotRunning(t *testing.T) {
+	b := &bidSimulator{
+		config:   &MevConfig{BidQueueTimeout: time.Minute},
+		exitCh:   make(chan struct{}),
+		newBidCh: make(chan *types.Bid),
+	}
+	bid := newTestBidRuntime(testBuilderA, 100).bid
+
+	// stopped simulator rejects the bid at once
+	if err := b.sendBid(context.Background(), bid); !errors.Is(err, types.ErrMevNotRunning) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// closing simulator stops waiting for the bid loop
+	b.start()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(b.exitCh)
+	}()
+
+	start := time.Now()
+	if err := b.sendBid(context.Background(), bid); !errors.Is(err, types.ErrMevNotRunning) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("bid rejected too late: %v", elapsed)
+	}
+}
+
+func TestSimBidMaxSimDuration(t *testing.T) {
+	b, backend := newTestBidSimulator(t, &MevConfig{
+		ValidatorCommission: 100,
+		Builders: []BuilderConfig{
+			{Address: testBuilderA, MaxSimDuration: time.Nanosecond},
+			{Address: testBuilderB},
+		},
+	})
+
+	// the bid of builderA can never be simulated in time
+	bidRuntime := newTestSimBid(backend, testBuilderA, params.TxGas, 1000)
+	b.simBid(make(chan int32, 1), bidRuntime)
+	if b.GetBestBid(bidRuntime.bid.ParentHash) != nil {
+		t.Fatal("bid simulated beyond the max simulation duration")
+	}
+
+	bidRuntime = newTestSimBid(backend, testBuilderB, params.TxGas, 1000)
+	b.simBid(make(chan int32, 1), bidRuntime)
+	if b.GetBestBid(bidRuntime.bid.ParentHash) == nil {
+		t.Fatal("bid without simulation limit not accepted")
+	}
+}
+
+func TestAddBuilderMaxBuilders(t *testing.T) {
+	b := &bidSimulator{
+		config:   &MevConfig{MaxBuilders: 1},
+		builders: make(map[common.Address]*builderclient.Client),
+	}
+
+	if err := b.AddBuilder(testBuilderA, ""); err != nil {
+		t.Fatalf("failed to add builder: %v", err)
+	}
+	// adding an existing builder again does not count
+	if err := b.AddBuilder(testBuilderA, ""); err != nil {
+		t.Fatalf("failed to add builder again: %v", err)
+	}
+	if err := b.AddBuilder(testBuilderB, ""); err == nil {
+		t.Fatal("expected error when exceeding max builders")
+	}
+	if b.ExistBuilder(testBuilderB) {
+		t.Fatal("builder added beyond the limit")
+	}
+
+	if err := b.RemoveBuilder(testBuilderA); err != nil {
+		t.Fatalf("failed to remove builder: %v", err)
+	}
+	if err := b.AddBuilder(testBuilderB, ""); err != nil {
+		t.Fatalf("failed to add builder after removal: %v", err)
+	}
+}
+
+func TestExpectingBids(t *testing.T) {
+	b := &bidSimulator{
+		config:   &MevConfig{},
+		builders: make(map[common.Address]*builderclient.Client),
+	}
+	b.start()
+	b.bidReceiving.Store(true)
+
+	if b.expectingBids() {
+		t.Fatal("bids expected without builders")
+	}
+	if err := b.AddBuilder(testBuilderA, ""); err != nil {
+		t.Fatalf("failed to add builder: %v", err)
+	}
+	if !b.expectingBids() {
+		t.Fatal("bids not expected")
+	}
+	b.stopReceivingBid()
+	if b.expectingBids() {
+		t.Fatal("bids expected with mev stopped")
+	}
+	b.bidReceiving.Store(true)
+	b.stop()
+	if b.expectingBids() {
+		t.Fatal("bids expected with the simulator stopped")
+	}
+}
+
+func TestRemoveBuilderBestBid(t *testing.T) {
+	b := &bidSimulator{
+		config:   &MevConfig{},
+		builders: make(map[common.Address]*builderclient.Client),
+		bestBid:  make(map[common.Hash]*BidRuntime),
+	}
+
+	bidA := newTestBidRuntime(testBuilderA, 100)
+	bidB := newTestBidRuntime(testBuilderB, 100)
+	bidB.bid.ParentHash = common.Hash{0x01}
+	b.SetBestBid(bidA.bid.ParentHash, bidA)
+	b.SetBestBid(bidB.bid.ParentHash, bidB)
+
+	if err := b.RemoveBuilder(testBuilderA); err != nil {
+		t.Fatalf("failed to remove builder: %v", err)
+	}
+	if b.GetBestBid(bidA.bid.ParentHash) != nil {
+		t.Fatal("best bid of the removed builder is kept")
+	}
+	if b.GetBestBid(bidB.bid.ParentHash) != bidB {
+		t.Fatal("best bid of another builder is dropped")
+	}
+}
+
+func TestSimBidNewBestBidEvent(t *testing.T) {
+	b, backend := newTestBidSimulator(t, &MevConfig{ValidatorCommission: 100})
+
+	ch := make(chan NewBestBidEvent, 1)
+	sub := b.newBestBidFeed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	bidRuntime := newTestSimBid(backend, testBuilderA, params.TxGas, 1000)
+	b.simBid(make(chan int32, 1), bidRuntime)
+
+	select {
+	case ev := <-ch:
+		if ev.Builder != testBuilderA || ev.BlockNumber != bidRuntime.bid.BlockNumber ||
+			ev.ParentHash != bidRuntime.bid.ParentHash || ev.TxCount != 1 || ev.Reward.Cmp(big.NewInt(1000)) != 0 {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+		if ev.Reward == bidRuntime.packedBlockReward {
+			t.Fatal("reward shared with subscribers")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no event for the new best bid")
+	}
+}
+
+func TestPostNewBestBidDrop(t *testing.T) {
+	b := &bidSimulator{newBestBidCh: make(chan NewBestBidEvent, 2)}
+
+	// nobody dispatches the events, the ones beyond the buffer are dropped without blocking
+	for i := uint64(1); i <= 3; i++ {
+		b.postNewBestBid(NewBestBidEvent{BlockNumber: i})
+	}
+
+	if len(b.newBestBidCh) != 2 {
+		t.Fatalf("unexpected queued events %d", len(b.newBestBidCh))
+	}
+	for i := uint64(1); i <= 2; i++ {
+		if ev := <-b.newBestBidCh; ev.BlockNumber != i {
+			t.Fatalf("unexpected event order, have %d want %d", ev.BlockNumber, i)
+		}
+	}
+}
+
+func TestAcquireBidSlot(t *testing.T) {
+	b := &bidSimulator{
+		config:    &MevConfig{MaxInFlightBids: 3, MaxInFlightPerBuilder: 2},
+		receiving: make(map[common.Address]int),
+	}
+
+	releaseA1, err := b.acquireBidSlot(testBuilderA)
+	if err != nil {
+		t.Fatalf("failed to acquire slot: %v", err)
+	}
+	if _, err = b.acquireBidSlot(testBuilderA); err != nil {
+		t.Fatalf("failed to acquire slot: %v", err)
+	}
+	// builderA reaches its own limit
+	if _, err = b.acquireBidSlot(testBuilderA); err == nil {
+		t.Fatal("expected error when exceeding the builder limit")
+	}
+	if _, err = b.acquireBidSlot(testBuilderB); err != nil {
+		t.Fatalf("failed to acquire slot: %v", err)
+	}
+	// all builders reach the global limit
+	if _, err = b.acquireBidSlot(testBuilderB); !errors.Is(err, types.ErrMevBusy) {
+		t.Fatalf("unexpected error when exceeding the global limit: %v", err)
+	}
+
+	releaseA1()
+	if _, err = b.acquireBidSlot(testBuilderA); err != nil {
+		t.Fatalf("failed to acquire slot after release: %v", err)
+	}
+}
+
+func TestCheckBidInterval(t *testing.T) {
+	b := &bidSimulator{
+		config: &MevConfig{
+			Builders: []BuilderConfig{{Address: testBuilderA, MinBidInterval: 50 * time.Millisecond}},
+		},
+		lastBid: make(map[common.Address]time.Time),
+	}
+
+	cancel, err := b.CheckBidInterval(testBuilderA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the interval is reserved before the first bid is queued
+	if _, err := b.CheckBidInterval(testBuilderA); err == nil {
+		t.Fatal("expected error for a concurrent bid")
+	}
+	// the first bid is rejected later and gives the interval back
+	cancel()
+	if _, err := b.CheckBidInterval(testBuilderA); err != nil {
+		t.Fatalf("unexpected error after the interval is given back: %v", err)
+	}
+	if _, err := b.CheckBidInterval(testBuilderA); err == nil {
+		t.Fatal("expected error for a bid within the interval")
+	}
+	// builders without interval are not limited
+	for i := 0; i < 2; i++ {
+		if _, err := b.CheckBidInterval(testBuilderB); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	cancel, err = b.CheckBidInterval(testBuilderA)
+	if err != nil {
+		t.Fatalf("unexpected error after the interval: %v", err)
+	}
+	// giving the interval back restores the previous bid, which is out of the interval
+	cancel()
+	if _, err := b.CheckBidInterval(testBuilderA); err != nil {
+		t.Fatalf("unexpected error after the interval is given back: %v", err)
+	}
+}
+
+func TestCheckBuilderFee(t *testing.T) {
+	b := &bidSimulator{}
+	bid := newTestBidRuntime(testBuilderA, 100).bid
+	bid.BuilderFee = big.NewInt(10)
+
+	// no ceil
+	if err := b.CheckBuilderFee(bid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		ceil  int64
+		valid bool
+	}{
+		{11, true},
+		{10, true},
+		{9, false},
+	}
+	for _, tt := range tests {
+		b.SetBuilderFeeCeil(big.NewInt(tt.ceil))
+		if err := b.CheckBuilderFee(bid); (err == nil) != tt.valid {
+			t.Errorf("ceil %d: unexpected error %v", tt.ceil, err)
+		}
+	}
+}
+
+func TestCheckLateBid(t *testing.T) {
+	b := &bidSimulator{
+		config:  &MevConfig{LateBidWindow: 100 * time.Millisecond, LateBidMargin: 1000},
+		bestBid: make(map[common.Hash]*BidRuntime),
+	}
+	bid := newTestBidRuntime(testBuilderB, 0).bid
+
+	// no best bid yet
+	bid.GasFee = big.NewInt(1)
+	if err := b.CheckLateBid(bid, 50*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b.SetBestBid(bid.ParentHash, newTestBidRuntime(testBuilderA, 1000))
+
+	tests := []struct {
+		timeLeft time.Duration
+		reward   int64
+		valid    bool
+	}{
+		// outside of the late window
+		{100 * time.Millisecond, 1001, true},
+		// within the late window
+		{50 * time.Millisecond, 1101, true},
+		{50 * time.Millisecond, 1100, false},
+		{50 * time.Millisecond, 1001, false},
+	}
+	for i, tt := range tests {
+		bid.GasFee = big.NewInt(tt.reward)
+		if err := b.CheckLateBid(bid, tt.timeLeft); (err == nil) != tt.valid {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestShouldSimulatePreferredBuilder(t *testing.T) {
+	b := &bidSimulator{
+		config:        &MevConfig{PreferredBuilder: testBuilderA, PreferredBuilderBand: 500},
+		bestBid:       make(map[common.Hash]*BidRuntime),
+		simulatingBid: make(map[common.Hash]*BidRuntime),
+	}
+	newBid := func(builder common.Address, reward int64) *BidRuntime {
+		bidRuntime := newTestBidRuntime(builder, reward)
+		bidRuntime.expectedValidatorReward = big.NewInt(reward / 10)
+		return bidRuntime
+	}
+
+	var (
+		bidX = newBid(testBuilderB, 100)
+		bidA = newBid(testBuilderA, 96)
+	)
+	b.SetBestBid(bidX.bid.ParentHash, bidX)
+
+	// the preferred bid is within the band of the best bid
+	if !b.shouldSimulate(bidA) {
+		t.Fatal("preferred bid is not simulated")
+	}
+	b.SetSimulatingBid(bidA.bid.ParentHash, bidA)
+
+	// better than the preferred bid in simulation, but worse than the best bid
+	if b.shouldSimulate(newBid(testBuilderB, 98)) {
+		t.Error("bid worse than the best bid interrupts the preferred bid")
+	}
+	// better than the best bid, but within the band of the preferred bid
+	if b.shouldSimulate(newBid(testBuilderB, 101)) {
+		t.Error("bid within the band interrupts the preferred bid")
+	}
+	// beyond the band of the preferred bid
+	if !b.shouldSimulate(newBid(testBuilderB, 110)) {
+		t.Error("much better bid is not simulated")
+	}
+}
@@ -285,6 +285,33 @@ func (miner *Miner) SetGasCeil(ceil uint64) {
 	miner.worker.setGasCeil(ceil)
 }
 
+// MiningConfig is a snapshot of the mining configuration in effect.
+type MiningConfig struct {
+	Etherbase     common.Address
+	ExtraData     hexutil.Bytes
+	GasCeil       uint64
+	GasTip        *big.Int
+	Recommit      time.Duration // The interval applied to re-create sealing work
+	DelayLeftOver time.Duration
+	MevRunning    bool
+	Mev           *types.MevParams
+}
+
+// MiningConfig returns the mining configuration in effect, including the values
+// updated at runtime.
+func (miner *Miner) MiningConfig() *MiningConfig {
+	return &MiningConfig{
+		Etherbase:     miner.worker.etherbase(),
+		ExtraData:     miner.worker.getExtra(),
+		GasCeil:       miner.worker.getGasCeil(),
+		GasTip:        miner.worker.getGasTip(),
+		Recommit:      miner.worker.getRecommitInterval(),
+		DelayLeftOver: miner.bidSimulator.delayLeftOver, // the same as in MevParams
+		MevRunning:    miner.MevRunning(),
+		Mev:           miner.MevParams(),
+	}
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
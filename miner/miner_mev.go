@@ -7,22 +7,42 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bidutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 type BuilderConfig struct {
-	Address common.Address
-	URL     string
+	Address        common.Address
+	URL            string
+	MaxSimDuration time.Duration // The maximum time to simulate a bid of the builder, 0 means no limit
+	MinBidInterval time.Duration // The minimum time between two bids of the builder, 0 means no limit
 }
 
 type MevConfig struct {
 	Enabled               bool            // Whether to enable Mev or not
-	BuilderFeeCeil        string          // The maximum builder fee of a bid
+	BuilderFeeCeil        string          // The maximum builder fee of a bid, empty means no ceil
 	SentryURL             string          // The url of Mev sentry
 	Builders              []BuilderConfig // The list of builders
+	MaxBuilders           int             // The maximum number of builders, 0 means no limit
 	ValidatorCommission   uint64          // 100 means 1%
 	BidSimulationLeftOver time.Duration
+	BidQueueTimeout       time.Duration    // The maximum time a bid waits to be queued for judging
+	GasUsedTolerance      uint64           // 100 means 1%, how much a bid's gasUsed may be understated compared to the simulated one
+	MaxBidSize            uint64           // The maximum total size in bytes of the txs in a bid, 0 means no limit
+	MaxInFlightBids       int              // The maximum number of bids being received at the same time, 0 means no limit
+	MaxInFlightPerBuilder int              // The maximum number of bids being received from a builder at the same time, 0 means no limit
+	DeniedTxTargets       []common.Address // Bids containing a tx to any of these addresses are rejected
+	PreferredBuilder      common.Address   // The builder whose bid wins if its reward is within PreferredBuilderBand of the best
+	PreferredBuilderBand  uint64           // 100 means 1%
+	DominantBidPause      bool             // Whether to stop refilling the local block once a dominant bid is simulated
+	DominantBidMargin     uint64           // 100 means 1%, how much the reward of a bid must exceed the local one to be dominant
+	NoBidAlertBlocks      uint64           // The number of consecutive in-turn blocks without any bid to raise an alert, 0 means disabled
+	BidFilterTimeout      time.Duration    // The maximum time the bid filter may take to decide
+	BidFilterFailOpen     bool             // Whether to accept bids when the bid filter fails to decide
+	LateBidWindow         time.Duration    // The time before the bid deadline in which only much better bids are accepted, 0 means disabled
+	LateBidMargin         uint64           // 100 means 1%, how much the reward of a late bid must exceed the best bid
+	MinBidTxs             int              // The minimum number of txs in a bid, not counting the payBidTx, 0 means no limit
 }
 
 var DefaultMevConfig = MevConfig{
@@ -31,6 +51,10 @@ var DefaultMevConfig = MevConfig{
 	Builders:              nil,
 	ValidatorCommission:   100,
 	BidSimulationLeftOver: 50 * time.Millisecond,
+	BidQueueTimeout:       1 * time.Second,
+	GasUsedTolerance:      500,
+	MaxBidSize:            10 * 1024 * 1024, // same as the maximum eth protocol message size
+	BidFilterTimeout:      defaultBidFilterTimeout,
 }
 
 // MevRunning return true if mev is running.
@@ -58,44 +82,161 @@ func (miner *Miner) RemoveBuilder(builderAddr common.Address) error {
 	return miner.bidSimulator.RemoveBuilder(builderAddr)
 }
 
+// SetBuilderFeeCeil sets the maximum builder fee of a bid, bids exceeding it are rejected.
+// nil removes the ceil.
+func (miner *Miner) SetBuilderFeeCeil(ceil *big.Int) {
+	miner.bidSimulator.SetBuilderFeeCeil(ceil)
+}
+
+// AddDeniedTxTarget denies bids containing a tx to the given address.
+func (miner *Miner) AddDeniedTxTarget(target common.Address) {
+	miner.bidSimulator.AddDeniedTxTarget(target)
+}
+
+// RemoveDeniedTxTarget allows bids containing a tx to the given address again.
+func (miner *Miner) RemoveDeniedTxTarget(target common.Address) {
+	miner.bidSimulator.RemoveDeniedTxTarget(target)
+}
+
+// SetBidSelector replaces the strategy to select the bid to seal, which decides the bids
+// to simulate, the best bid and whether it beats the local block. nil restores the default
+// one which selects the bid with the highest rewards.
+func (miner *Miner) SetBidSelector(selector BidSelector) {
+	miner.bidSimulator.SetBidSelector(selector)
+}
+
+// SetBidFilter sets the policy to accept or reject simulated bids before they compete
+// for the best bid, nil removes it.
+func (miner *Miner) SetBidFilter(filter BidFilter) {
+	miner.bidSimulator.SetBidFilter(filter)
+}
+
+// SubscribeNewBestBid starts delivering the bids that become the best bid of their
+// parent to the given channel. Events are delivered in order, and dropped rather than
+// delaying the simulation if subscribers do not keep up.
+func (miner *Miner) SubscribeNewBestBid(ch chan<- NewBestBidEvent) event.Subscription {
+	return miner.bidSimulator.newBestBidFeed.Subscribe(ch)
+}
+
+// SendBid judges the bid received by the MEV API and queues it for simulation, the API
+// counts the bid as received and the rejections are counted by reason.
 func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (common.Hash, error) {
+	// check the size before hashing and decoding the txs
+	if err := checkBidSize(bidArgs, miner.worker.config.Mev.MaxBidSize); err != nil {
+		return common.Hash{}, bidutil.RejectBid("size", err)
+	}
+
+	if err := checkBidTxCount(bidArgs, miner.worker.config.Mev.MinBidTxs); err != nil {
+		return common.Hash{}, bidutil.RejectBid("txcount", err)
+	}
+
 	builder, err := bidArgs.EcrecoverSender()
 	if err != nil {
-		return common.Hash{}, types.NewInvalidBidError(fmt.Sprintf("invalid signature:%v", err))
+		return common.Hash{}, bidutil.RejectBid("signature", types.NewInvalidBidError(fmt.Sprintf("invalid signature:%v", err)))
 	}
 
 	if !miner.bidSimulator.ExistBuilder(builder) {
-		return common.Hash{}, types.NewInvalidBidError("builder is not registered")
+		return common.Hash{}, bidutil.RejectBid("builder", types.NewInvalidBidError("builder is not registered"))
 	}
 
+	// only registered builders are counted, which bounds the number of metrics
+	bidutil.MarkBid(fmt.Sprintf("received/%v", builder))
+
 	err = miner.bidSimulator.CheckPending(bidArgs.RawBid.BlockNumber, builder, bidArgs.RawBid.Hash())
 	if err != nil {
-		return common.Hash{}, err
+		return common.Hash{}, bidutil.RejectBid("pending", err)
+	}
+
+	cancelInterval, err := miner.bidSimulator.CheckBidInterval(builder)
+	if err != nil {
+		return common.Hash{}, bidutil.RejectBid("interval", err)
+	}
+	// give the interval back if the bid is not queued in the end
+	queued := false
+	defer func() {
+		if !queued {
+			cancelInterval()
+		}
+	}()
+
+	// decoding txs is expensive, do not let a single builder occupy it
+	release, err := miner.bidSimulator.acquireBidSlot(builder)
+	if err != nil {
+		return common.Hash{}, bidutil.RejectBid("inflight", err)
 	}
+	defer release()
 
 	signer := types.MakeSigner(miner.worker.chainConfig, big.NewInt(int64(bidArgs.RawBid.BlockNumber)), uint64(time.Now().Unix()))
 	bid, err := bidArgs.ToBid(builder, signer)
 	if err != nil {
-		return common.Hash{}, types.NewInvalidBidError(fmt.Sprintf("fail to convert bidArgs to bid, %v", err))
+		return common.Hash{}, bidutil.RejectBid("decode", types.NewInvalidBidError(fmt.Sprintf("fail to convert bidArgs to bid, %v", err)))
+	}
+
+	if err = miner.bidSimulator.CheckBuilderFee(bid); err != nil {
+		return common.Hash{}, bidutil.RejectBid("fee", err)
+	}
+
+	if err = miner.bidSimulator.CheckTxTargets(bid); err != nil {
+		return common.Hash{}, bidutil.RejectBid("target", err)
 	}
 
 	bidBetterBefore := miner.bidSimulator.bidBetterBefore(bidArgs.RawBid.ParentHash)
 	timeout := time.Until(bidBetterBefore)
 
 	if timeout <= 0 {
-		return common.Hash{}, fmt.Errorf("too late, expected befor %s, appeared %s later", bidBetterBefore,
-			common.PrettyDuration(timeout))
+		return common.Hash{}, bidutil.RejectBid("late", fmt.Errorf("too late, expected befor %s, appeared %s later", bidBetterBefore,
+			common.PrettyDuration(timeout)))
+	}
+
+	if err = miner.bidSimulator.CheckLateBid(bid, timeout); err != nil {
+		return common.Hash{}, bidutil.RejectBid("latevalue", err)
 	}
 
+	// the bid is useless if it can not be queued before bidBetterBefore
+	ctx, cancel := context.WithDeadline(ctx, bidBetterBefore)
+	defer cancel()
+
 	err = miner.bidSimulator.sendBid(ctx, bid)
 
 	if err != nil {
-		return common.Hash{}, err
+		return common.Hash{}, bidutil.RejectBid("queue", err)
 	}
 
+	queued = true
+
+	bidutil.MarkBid("accepted")
+
 	return bid.Hash(), nil
 }
 
+// checkBidSize returns an error if the total size of the txs in the bid exceeds maxSize.
+func checkBidSize(bidArgs *types.BidArgs, maxSize uint64) error {
+	if maxSize == 0 {
+		return nil
+	}
+
+	size := uint64(len(bidArgs.PayBidTx))
+	for _, tx := range bidArgs.RawBid.Txs {
+		size += uint64(len(tx))
+	}
+
+	if size > maxSize {
+		return types.NewInvalidBidError(fmt.Sprintf("bid is too large, size %d, max %d", size, maxSize))
+	}
+
+	return nil
+}
+
+// checkBidTxCount returns an error if the bid has fewer txs than minTxs, the payBidTx
+// is not counted as it only pays the builder.
+func checkBidTxCount(bidArgs *types.BidArgs, minTxs int) error {
+	if count := len(bidArgs.RawBid.Txs); count < minTxs {
+		return types.NewInvalidBidError(fmt.Sprintf("too few txs in bid, count %d, min %d", count, minTxs))
+	}
+
+	return nil
+}
+
 func (miner *Miner) BestPackedBlockReward(parentHash common.Hash) *big.Int {
 	bidRuntime := miner.bidSimulator.GetBestBid(parentHash)
 	if bidRuntime == nil {
@@ -106,16 +247,16 @@ func (miner *Miner) BestPackedBlockReward(parentHash common.Hash) *big.Int {
 }
 
 func (miner *Miner) MevParams() *types.MevParams {
-	builderFeeCeil, ok := big.NewInt(0).SetString(miner.worker.config.Mev.BuilderFeeCeil, 10)
-	if !ok {
-		log.Error("failed to parse builder fee ceil", "BuilderFeeCeil", miner.worker.config.Mev.BuilderFeeCeil)
-		return nil
+	var builderFeeCeil *big.Int
+	if ceil := miner.bidSimulator.BuilderFeeCeil(); ceil != nil {
+		builderFeeCeil = new(big.Int).Set(ceil)
 	}
 
 	return &types.MevParams{
 		ValidatorCommission:   miner.worker.config.Mev.ValidatorCommission,
 		BidSimulationLeftOver: miner.worker.config.Mev.BidSimulationLeftOver,
-		GasCeil:               miner.worker.config.GasCeil,
+		DelayLeftOver:         miner.bidSimulator.delayLeftOver,
+		GasCeil:               miner.worker.getGasCeil(),
 		BuilderFeeCeil:        builderFeeCeil,
 	}
 }
@@ -0,0 +1,202 @@
+package miner
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCheckBidSize(t *testing.T) {
+	bidArgs := &types.BidArgs{
+		RawBid: &types.RawBid{
+			Txs: []hexutil.Bytes{make([]byte, 400), make([]byte, 500)},
+		},
+		PayBidTx: make([]byte, 100),
+	}
+
+	tests := []struct {
+		maxSize uint64
+		valid   bool
+	}{
+		{0, true},
+		{1001, true},
+		{1000, true},
+		{999, false},
+	}
+
+	for _, tt := range tests {
+		if err := checkBidSize(bidArgs, tt.maxSize); (err == nil) != tt.valid {
+			t.Errorf("max size %d: unexpected error %v", tt.maxSize, err)
+		}
+	}
+}
+
+func TestCheckBidTxCount(t *testing.T) {
+	bidArgs := &types.BidArgs{
+		RawBid: &types.RawBid{
+			Txs: []hexutil.Bytes{{0x01}, {0x02}, {0x03}},
+		},
+		PayBidTx: hexutil.Bytes{0x04},
+	}
+
+	tests := []struct {
+		minTxs int
+		valid  bool
+	}{
+		{0, true},
+		{3, true},
+		{4, false},
+	}
+
+	for _, tt := range tests {
+		if err := checkBidTxCount(bidArgs, tt.minTxs); (err == nil) != tt.valid {
+			t.Errorf("min txs %d: unexpected error %v", tt.minTxs, err)
+		}
+	}
+}
+
+func TestSendBidMetrics(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	miner, _, cleanup := createMiner(t)
+	defer cleanup(false)
+
+	var (
+		parent  = miner.worker.chain.Genesis()
+		signer  = types.LatestSigner(miner.worker.chainConfig)
+		to      = common.Address{0x01}
+		nonce   uint64
+		mev     = &miner.worker.config.Mev
+		key, _  = crypto.GenerateKey()
+		builder = crypto.PubkeyToAddress(key.PublicKey)
+	)
+	if err := miner.bidSimulator.AddBuilder(builder, ""); err != nil {
+		t.Fatalf("failed to add builder: %v", err)
+	}
+
+	// the genesis block is old, leave enough time for the bids on top of it
+	chainConfig := *miner.bidSimulator.chainConfig
+	chainConfig.Parlia = &params.ParliaConfig{Period: uint64(time.Now().Unix()) + 3600}
+	miner.bidSimulator.chainConfig = &chainConfig
+
+	count := func(name string) int64 {
+		return metrics.GetOrRegisterCounter("bid/"+name, nil).Snapshot().Count()
+	}
+	// every bid has its own tx so that the bids never share the same hash
+	newRawBid := func() *types.RawBid {
+		tx := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &to,
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+		nonce++
+		raw, _ := tx.MarshalBinary()
+		return &types.RawBid{
+			BlockNumber: parent.NumberU64() + 1,
+			ParentHash:  parent.Hash(),
+			Txs:         []hexutil.Bytes{raw},
+			GasUsed:     params.TxGas,
+			GasFee:      big.NewInt(1000),
+			BuilderFee:  big.NewInt(0),
+		}
+	}
+	sign := func(rawBid *types.RawBid) *types.BidArgs {
+		sig, _ := crypto.Sign(rawBid.Hash().Bytes(), key)
+		return &types.BidArgs{RawBid: rawBid, Signature: sig}
+	}
+	expectReject := func(reason string, bidArgs *types.BidArgs) {
+		t.Helper()
+		rejected := count("rejected/" + reason)
+		if _, err := miner.SendBid(context.Background(), bidArgs); err == nil {
+			t.Fatalf("%s: expected bid to be rejected", reason)
+		}
+		if have := count("rejected/"+reason) - rejected; have != 1 {
+			t.Errorf("%s: rejected counter increased by %d, want 1", reason, have)
+		}
+	}
+
+	mev.MaxBidSize = 10
+	expectReject("size", sign(newRawBid()))
+	mev.MaxBidSize = 0
+
+	mev.MinBidTxs = 2
+	expectReject("txcount", sign(newRawBid()))
+	mev.MinBidTxs = 0
+
+	expectReject("signature", &types.BidArgs{RawBid: newRawBid(), Signature: []byte{0x01}})
+
+	other, _ := crypto.GenerateKey()
+	sig, _ := crypto.Sign(newRawBid().Hash().Bytes(), other)
+	expectReject("builder", &types.BidArgs{RawBid: newRawBid(), Signature: sig})
+
+	bidArgs := sign(newRawBid())
+	_ = miner.bidSimulator.CheckPending(bidArgs.RawBid.BlockNumber, builder, bidArgs.RawBid.Hash())
+	miner.bidSimulator.AddPending(bidArgs.RawBid.BlockNumber, builder, bidArgs.RawBid.Hash())
+	expectReject("pending", bidArgs)
+
+	mev.Builders = []BuilderConfig{{Address: builder, MinBidInterval: time.Hour}}
+	if _, err := miner.bidSimulator.CheckBidInterval(builder); err != nil {
+		t.Fatalf("failed to reserve the bid interval: %v", err)
+	}
+	expectReject("interval", sign(newRawBid()))
+	mev.Builders = nil
+
+	mev.MaxInFlightPerBuilder = 1
+	release, err := miner.bidSimulator.acquireBidSlot(builder)
+	if err != nil {
+		t.Fatalf("failed to acquire bid slot: %v", err)
+	}
+	expectReject("inflight", sign(newRawBid()))
+	release()
+	mev.MaxInFlightPerBuilder = 0
+
+	rawBid := newRawBid()
+	rawBid.Txs = []hexutil.Bytes{{0x01}}
+	expectReject("decode", sign(rawBid))
+
+	rawBid = newRawBid()
+	rawBid.BuilderFee = big.NewInt(1)
+	miner.bidSimulator.SetBuilderFeeCeil(big.NewInt(0))
+	expectReject("fee", sign(rawBid))
+	miner.bidSimulator.SetBuilderFeeCeil(nil)
+
+	miner.bidSimulator.AddDeniedTxTarget(to)
+	expectReject("target", sign(newRawBid()))
+	miner.bidSimulator.RemoveDeniedTxTarget(to)
+
+	mev.BidSimulationLeftOver = 2 * time.Hour
+	expectReject("late", sign(newRawBid()))
+	mev.BidSimulationLeftOver = 0
+
+	mev.LateBidWindow = 2 * time.Hour
+	miner.bidSimulator.SetBestBid(parent.Hash(), newTestBidRuntime(builder, params.Ether))
+	expectReject("latevalue", sign(newRawBid()))
+	mev.LateBidWindow = 0
+
+	// the bid simulator is not started
+	expectReject("queue", sign(newRawBid()))
+
+	miner.bidSimulator.start()
+	accepted, received := count("accepted"), count(fmt.Sprintf("received/%v", builder))
+	if _, err := miner.SendBid(context.Background(), sign(newRawBid())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if have := count("accepted") - accepted; have != 1 {
+		t.Errorf("accepted counter increased by %d, want 1", have)
+	}
+	if have := count(fmt.Sprintf("received/%v", builder)) - received; have != 1 {
+		t.Errorf("builder counter increased by %d, want 1", have)
+	}
+}
@@ -250,6 +250,96 @@ func TestMinerSetEtherbase(t *testing.T) {
 	}
 }
 
+// TestMinerMevParams checks that the mev params reflect the live mining configuration.
+func TestMinerMevParams(t *testing.T) {
+	t.Parallel()
+	miner, _, cleanup := createMiner(t)
+	defer cleanup(false)
+
+	miner.SetBuilderFeeCeil(big.NewInt(0))
+	miner.bidSimulator.delayLeftOver = 100 * time.Millisecond
+	miner.SetGasCeil(40_000_000)
+
+	params := miner.MevParams()
+	if params == nil {
+		t.Fatal("Expected mev params")
+	}
+	if params.GasCeil != 40_000_000 {
+		t.Fatalf("Unexpected gas ceil want %d got %d", 40_000_000, params.GasCeil)
+	}
+	if params.DelayLeftOver != 100*time.Millisecond {
+		t.Fatalf("Unexpected delay left over want %v got %v", 100*time.Millisecond, params.DelayLeftOver)
+	}
+	if params.BuilderFeeCeil == nil || params.BuilderFeeCeil.Sign() != 0 {
+		t.Fatalf("Unexpected builder fee ceil %v", params.BuilderFeeCeil)
+	}
+
+	// no ceil
+	miner.SetBuilderFeeCeil(nil)
+	if params = miner.MevParams(); params == nil || params.BuilderFeeCeil != nil {
+		t.Fatalf("Unexpected mev params without builder fee ceil %+v", params)
+	}
+}
+
+func TestMinerMiningConfig(t *testing.T) {
+	t.Parallel()
+	miner, _, cleanup := createMiner(t)
+	defer cleanup(false)
+
+	miner.SetBuilderFeeCeil(big.NewInt(0))
+	miner.SetGasCeil(40_000_000)
+	miner.SetGasTip(big.NewInt(params.GWei))
+	miner.SetEtherbase(common.HexToAddress("0xbeef"))
+	if err := miner.SetExtra([]byte("extra")); err != nil {
+		t.Fatal(err)
+	}
+
+	config := miner.MiningConfig()
+	if config.GasCeil != 40_000_000 {
+		t.Fatalf("Unexpected gas ceil want %d got %d", 40_000_000, config.GasCeil)
+	}
+	if config.GasTip.Cmp(big.NewInt(params.GWei)) != 0 {
+		t.Fatalf("Unexpected gas tip got %v", config.GasTip)
+	}
+	if config.Etherbase != common.HexToAddress("0xbeef") {
+		t.Fatalf("Unexpected etherbase got %v", config.Etherbase)
+	}
+	if string(config.ExtraData) != "extra" {
+		t.Fatalf("Unexpected extra data got %q", config.ExtraData)
+	}
+	if config.Recommit != minRecommitInterval {
+		t.Fatalf("Unexpected recommit want %v got %v", minRecommitInterval, config.Recommit)
+	}
+	if config.Mev == nil || config.Mev.GasCeil != 40_000_000 {
+		t.Fatalf("Unexpected mev params %v", config.Mev)
+	}
+	if config.DelayLeftOver != config.Mev.DelayLeftOver {
+		t.Fatalf("Delay left over mismatch %v and %v", config.DelayLeftOver, config.Mev.DelayLeftOver)
+	}
+
+	// the recommit interval is applied by the work loop
+	miner.SetRecommitInterval(2 * time.Second)
+	for i := 0; i < 100 && miner.MiningConfig().Recommit != 2*time.Second; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if recommit := miner.MiningConfig().Recommit; recommit != 2*time.Second {
+		t.Fatalf("Unexpected recommit want %v got %v", 2*time.Second, recommit)
+	}
+	// payload building keeps the configured interval
+	if miner.worker.recommit != minRecommitInterval {
+		t.Fatalf("Unexpected payload recommit want %v got %v", minRecommitInterval, miner.worker.recommit)
+	}
+
+	// the sanitized interval is reported rather than the one given by the user
+	miner.SetRecommitInterval(time.Millisecond)
+	for i := 0; i < 100 && miner.MiningConfig().Recommit == 2*time.Second; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if recommit := miner.MiningConfig().Recommit; recommit != minRecommitInterval {
+		t.Fatalf("Unexpected recommit want %v got %v", minRecommitInterval, recommit)
+	}
+}
+
 // waitForMiningState waits until either
 // * the desired mining state was reached
 // * a timeout was reached which fails the test
@@ -71,6 +71,7 @@ const (
 var (
 	writeBlockTimer    = metrics.NewRegisteredTimer("worker/writeblock", nil)
 	finalizeBlockTimer = metrics.NewRegisteredTimer("worker/finalizeblock", nil)
+	noBidBlocksGauge   = metrics.NewRegisteredGauge("worker/nobidblocks", nil)
 
 	errBlockInterruptedByNewHead  = errors.New("new head arrived while building block")
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
@@ -167,6 +168,8 @@ type getWorkReq struct {
 
 type bidFetcher interface {
 	GetBestBid(parentHash common.Hash) *BidRuntime
+	getBidSelector() BidSelector
+	expectingBids() bool
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -201,10 +204,14 @@ type worker struct {
 
 	current *environment // An environment for current running cycle.
 
-	mu       sync.RWMutex // The lock used to protect the coinbase and extra fields
-	coinbase common.Address
-	extra    []byte
-	tip      *uint256.Int // Minimum tip needed for non-local transaction to include them
+	noBidBlocks      uint64 // The number of consecutive in-turn blocks built without any bid
+	noBidCheckNumber uint64 // The number of the last block checked by watchBids
+
+	mu              sync.RWMutex // The lock used to protect the coinbase, extra and sealing recommit fields
+	coinbase        common.Address
+	extra           []byte
+	tip             *uint256.Int  // Minimum tip needed for non-local transaction to include them
+	sealingRecommit time.Duration // The interval newWorkLoop applies to re-create sealing work
 
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
@@ -275,6 +282,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		recommit = minRecommitInterval
 	}
 	worker.recommit = recommit
+	worker.sealingRecommit = recommit
 
 	// Sanitize the timeout config for creating payload.
 	newpayloadTimeout := worker.config.NewPayloadTimeout
@@ -325,6 +333,13 @@ func (w *worker) setGasCeil(ceil uint64) {
 	w.config.GasCeil = ceil
 }
 
+// getGasCeil returns the gas ceiling the worker strives for.
+func (w *worker) getGasCeil() uint64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config.GasCeil
+}
+
 // setExtra sets the content used to initialize the block extra field.
 func (w *worker) setExtra(extra []byte) {
 	w.mu.Lock()
@@ -332,6 +347,13 @@ func (w *worker) setExtra(extra []byte) {
 	w.extra = extra
 }
 
+// getExtra returns the content used to initialize the block extra field.
+func (w *worker) getExtra() []byte {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return common.CopyBytes(w.extra)
+}
+
 // setGasTip sets the minimum miner tip needed to include a non-local transaction.
 func (w *worker) setGasTip(tip *big.Int) {
 	w.mu.Lock()
@@ -339,6 +361,21 @@ func (w *worker) setGasTip(tip *big.Int) {
 	w.tip = uint256.MustFromBig(tip)
 }
 
+// getGasTip returns the minimum miner tip needed to include a non-local transaction.
+func (w *worker) getGasTip() *big.Int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.tip.ToBig()
+}
+
+// getRecommitInterval returns the interval newWorkLoop applies for miner sealing work
+// recommitting, including the updates by the user. Payload building keeps the configured one.
+func (w *worker) getRecommitInterval() time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sealingRecommit
+}
+
 // setRecommitInterval updates the interval for miner sealing work recommitting.
 func (w *worker) setRecommitInterval(interval time.Duration) {
 	select {
@@ -481,6 +518,10 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 			log.Info("Miner recommit interval update", "from", minRecommit, "to", interval)
 			minRecommit, recommit = interval, interval
 
+			w.mu.Lock()
+			w.sealingRecommit = recommit
+			w.mu.Unlock()
+
 			if w.resubmitHook != nil {
 				w.resubmitHook(minRecommit, recommit)
 			}
@@ -1239,6 +1280,12 @@ LOOP:
 			break
 		}
 
+		if w.dominatedByBid(work) {
+			// the local block can hardly catch up with the best bid, save the CPU for it,
+			// but keep waiting as better bids may still arrive before the block is sealed.
+			log.Debug("commitWork pause refilling, best bid dominates", "block", work.header.Number)
+		}
+
 		newTxsNum := 0
 		// stopTimer was the maximum delay for each fillTransactions
 		// but now it is used to wait until (head.Time - DelayLeftOver) is reached.
@@ -1253,6 +1300,11 @@ LOOP:
 				log.Debug("commitWork interruptCh closed, new block imported or resubmit triggered")
 				return
 			case ev := <-txsCh:
+				if w.dominatedByBid(work) {
+					// refilling is paused, it resumes once the best bid is invalidated
+					newTxsNum = newTxsNum + len(ev.Txs)
+					continue
+				}
 				delay := w.engine.Delay(w.chain, work.header, &w.config.DelayLeftOver)
 				log.Debug("commitWork txsCh arrived", "fillDuration", fillDuration.String(),
 					"delay", delay.String(), "work.tcount", work.tcount,
@@ -1278,7 +1330,7 @@ LOOP:
 					stopWaitTimer.Reset(*delay - fillDuration*2)
 				}
 			case <-stopWaitTimer.C:
-				if newTxsNum > 0 {
+				if newTxsNum > 0 && !w.dominatedByBid(work) {
 					break LOOP_WAIT
 				}
 			}
@@ -1306,17 +1358,11 @@ LOOP:
 	from := bestWork.coinbase
 	if w.bidFetcher != nil && bestWork.header.Difficulty.Cmp(diffInTurn) == 0 {
 		bestBid := w.bidFetcher.GetBestBid(bestWork.header.ParentHash)
+		w.watchBids(bestWork.header, bestBid != nil)
 
-		if bestBid != nil && bestReward.CmpBig(bestBid.packedBlockReward) < 0 {
-			// localValidatorReward is the reward for the validator self by the local block.
-			localValidatorReward := new(uint256.Int).Mul(bestReward, uint256.NewInt(w.config.Mev.ValidatorCommission))
-			localValidatorReward.Div(localValidatorReward, uint256.NewInt(10000))
-
-			// blockReward(benefits delegators) and validatorReward(benefits the validator) are both optimal
-			if localValidatorReward.CmpBig(bestBid.packedValidatorReward) < 0 {
-				bestWork = bestBid.env
-				from = bestBid.bid.Builder
-			}
+		if bestBid != nil && w.bidFetcher.getBidSelector().IsBetterThanLocal(bestBid, bestReward.ToBig()) {
+			bestWork = bestBid.env
+			from = bestBid.bid.Builder
 		}
 	}
 
@@ -1332,6 +1378,75 @@ LOOP:
 	w.current = bestWork
 }
 
+// watchBids counts the consecutive in-turn blocks built without any bid and raises an
+// alert once the configured limit is reached, which usually means the builders can not
+// reach the validator anymore. Blocks are only counted while bids are expected, that is
+// bids are being received and builders are registered. It reports whether the alert is raised.
+func (w *worker) watchBids(header *types.Header, hasBid bool) bool {
+	limit := w.config.Mev.NoBidAlertBlocks
+	if limit == 0 {
+		return false
+	}
+
+	if w.bidFetcher == nil || !w.bidFetcher.expectingBids() {
+		// start over once bids are expected again
+		w.noBidBlocks = 0
+		noBidBlocksGauge.Update(0)
+		return false
+	}
+
+	// the work of a block may be committed more than once, count it only once
+	number := header.Number.Uint64()
+	if number == w.noBidCheckNumber {
+		return false
+	}
+	w.noBidCheckNumber = number
+
+	if hasBid {
+		if w.noBidBlocks >= limit {
+			log.Info("Bids resumed", "block", number, "blocksWithoutBid", w.noBidBlocks)
+		}
+		w.noBidBlocks = 0
+		noBidBlocksGauge.Update(0)
+		return false
+	}
+
+	w.noBidBlocks++
+	noBidBlocksGauge.Update(int64(w.noBidBlocks))
+	if w.noBidBlocks < limit {
+		return false
+	}
+
+	log.Error("No bids received for in-turn blocks, check the builders", "block", number, "blocksWithoutBid", w.noBidBlocks)
+	return true
+}
+
+// dominatedByBid reports whether the best bid on the same parent would replace the local
+// work at seal time by far: its block reward exceeds the local one by more than the
+// configured margin, and its validator reward exceeds the local one as well. While it is
+// true the local work is not refilled, refilling resumes once the best bid is invalidated.
+func (w *worker) dominatedByBid(work *environment) bool {
+	if !w.config.Mev.DominantBidPause || w.bidFetcher == nil || work.header.Difficulty.Cmp(diffInTurn) != 0 {
+		return false
+	}
+
+	bestBid := w.bidFetcher.GetBestBid(work.header.ParentHash)
+	if bestBid == nil {
+		return false
+	}
+
+	localReward := work.state.GetBalance(consensus.SystemAddress).ToBig()
+	threshold := new(big.Int).Mul(localReward, big.NewInt(int64(10000+w.config.Mev.DominantBidMargin)))
+	threshold.Div(threshold, big.NewInt(10000))
+
+	if bestBid.packedBlockReward.Cmp(threshold) <= 0 {
+		return false
+	}
+
+	// the same check as the one choosing between the bid and the local work in commitWork
+	return w.bidFetcher.getBidSelector().IsBetterThanLocal(bestBid, localReward)
+}
+
 // inTurn return true if the current worker is in turn.
 func (w *worker) inTurn() bool {
 	validator, _ := w.engine.NextInTurnValidator(w.chain, w.chain.CurrentBlock())
@@ -18,6 +18,8 @@ package miner // TOFIX
 
 import (
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -411,3 +413,219 @@ func testGetSealingWork(t *testing.T, chainConfig *params.ChainConfig, engine co
 		}
 	}
 }
+
+type testBidFetcher struct {
+	mu        sync.Mutex
+	bestBid   *BidRuntime
+	selector  BidSelector
+	expecting bool
+}
+
+func (f *testBidFetcher) expectingBids() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.expecting
+}
+
+func (f *testBidFetcher) getBidSelector() BidSelector {
+	return f.selector
+}
+
+func (f *testBidFetcher) GetBestBid(common.Hash) *BidRuntime {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.bestBid
+}
+
+func (f *testBidFetcher) setBestBid(bid *BidRuntime) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bestBid = bid
+}
+
+// delayEngine makes the worker refill the local block until the block time as parlia
+// does, the block time is fixed so it is the same for every refill.
+type delayEngine struct {
+	*clique.Clique
+	blockTime uint64
+	prepared  atomic.Int32
+}
+
+func (e *delayEngine) Prepare(chain consensus.ChainHeaderReader, header *types.Header) error {
+	if err := e.Clique.Prepare(chain, header); err != nil {
+		return err
+	}
+	header.Time = e.blockTime
+	e.prepared.Add(1)
+	return nil
+}
+
+func (e *delayEngine) Delay(_ consensus.ChainReader, header *types.Header, leftOver *time.Duration) *time.Duration {
+	delay := time.Until(time.Unix(int64(header.Time), 0)) - *leftOver
+	return &delay
+}
+
+func TestDominatedByBid(t *testing.T) {
+	t.Parallel()
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllCliqueProtocolChanges
+	)
+	config.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := clique.New(config.Clique, db)
+
+	minerConfig := *testConfig
+	minerConfig.Mev = MevConfig{DominantBidPause: true, DominantBidMargin: 1000, ValidatorCommission: 100}
+	backend := newTestWorkerBackend(t, &config, engine, db, 0)
+	w := newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, false)
+	w.setEtherbase(testBankAddress)
+	defer w.close()
+
+	fetcher := &testBidFetcher{selector: &rewardBidSelector{config: &minerConfig.Mev}}
+	w.setBestBidFetcher(fetcher)
+
+	work, err := w.prepareWork(&generateParams{coinbase: testBankAddress})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	defer work.discard()
+	work.state.AddBalance(consensus.SystemAddress, uint256.NewInt(1000))
+
+	// the local block reward is 1000, and the local validator reward is 10
+	tests := []struct {
+		blockReward     int64
+		validatorReward int64
+		dominated       bool
+	}{
+		{1100, 100, false},
+		{1101, 100, true},
+		// the builder fee leaves the validator less than the local block
+		{1101, 10, false},
+		{1101, 11, true},
+	}
+
+	fetcher.setBestBid(nil)
+	if w.dominatedByBid(work) {
+		t.Error("dominated without best bid")
+	}
+	for i, tt := range tests {
+		bid := newTestBidRuntime(testBuilderA, tt.blockReward)
+		bid.packedValidatorReward = big.NewInt(tt.validatorReward)
+		fetcher.setBestBid(bid)
+		if got := w.dominatedByBid(work); got != tt.dominated {
+			t.Errorf("test %d: dominated = %v, want %v", i, got, tt.dominated)
+		}
+	}
+
+	minerConfig.Mev.DominantBidPause = false
+	bid := newTestBidRuntime(testBuilderA, 1101)
+	bid.packedValidatorReward = big.NewInt(100)
+	fetcher.setBestBid(bid)
+	if w.dominatedByBid(work) {
+		t.Error("dominated with pause disabled")
+	}
+}
+
+func TestCommitWorkDominantBidPause(t *testing.T) {
+	t.Parallel()
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllCliqueProtocolChanges
+	)
+	config.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := &delayEngine{Clique: clique.New(config.Clique, db), blockTime: uint64(time.Now().Unix()) + 3}
+	backend := newTestWorkerBackend(t, &config, engine.Clique, db, 0)
+
+	minerConfig := *testConfig
+	minerConfig.Mev = MevConfig{DominantBidPause: true, DominantBidMargin: 1000, ValidatorCommission: 100}
+	w := newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, false)
+	w.setEtherbase(testBankAddress)
+	defer w.close()
+
+	fetcher := &testBidFetcher{selector: &rewardBidSelector{config: &minerConfig.Mev}}
+	bid := newTestBidRuntime(testBuilderA, params.Ether)
+	bid.packedValidatorReward = big.NewInt(params.Ether)
+	fetcher.setBestBid(bid)
+	w.setBestBidFetcher(fetcher)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.commitWork(make(chan int32, 1), time.Now().Unix())
+	}()
+
+	addTxs := func(d time.Duration) {
+		for end := time.Now().Add(d); time.Now().Before(end); time.Sleep(50 * time.Millisecond) {
+			backend.txPool.Add([]*types.Transaction{backend.newRandomTx(false)}, true, false)
+		}
+	}
+
+	// the bid dominates the local block, new txs do not trigger refilling
+	addTxs(500 * time.Millisecond)
+	if n := engine.prepared.Load(); n != 1 {
+		t.Fatalf("local block prepared %d times while paused, want 1", n)
+	}
+
+	// the bid is invalidated, refilling resumes on new txs
+	fetcher.setBestBid(nil)
+	addTxs(500 * time.Millisecond)
+	if n := engine.prepared.Load(); n < 2 {
+		t.Fatalf("local block prepared %d times after the bid is invalidated, want more than 1", n)
+	}
+
+	<-done
+}
+
+func TestWatchBids(t *testing.T) {
+	t.Parallel()
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		config = *params.AllCliqueProtocolChanges
+	)
+	config.Clique = &params.CliqueConfig{Period: 1, Epoch: 30000}
+	engine := clique.New(config.Clique, db)
+
+	minerConfig := *testConfig
+	minerConfig.Mev = MevConfig{Enabled: true, NoBidAlertBlocks: 3}
+	backend := newTestWorkerBackend(t, &config, engine, db, 0)
+	w := newWorker(&minerConfig, &config, engine, backend, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	fetcher := &testBidFetcher{expecting: true}
+	w.setBestBidFetcher(fetcher)
+
+	tests := []struct {
+		number uint64
+		hasBid bool
+		alert  bool
+	}{
+		{1, false, false},
+		{2, false, false},
+		// the same block is counted once
+		{2, false, false},
+		{3, false, true},
+		{4, false, true},
+		// a bid resets the watchdog
+		{5, true, false},
+		{6, false, false},
+	}
+	for i, tt := range tests {
+		header := &types.Header{Number: new(big.Int).SetUint64(tt.number)}
+		if got := w.watchBids(header, tt.hasBid); got != tt.alert {
+			t.Errorf("test %d: alert = %v, want %v", i, got, tt.alert)
+		}
+	}
+
+	// no bids are expected while mev is stopped, and the count starts over
+	for number := uint64(7); number < 10; number++ {
+		fetcher.expecting = number != 8
+		if w.watchBids(&types.Header{Number: new(big.Int).SetUint64(number)}, false) {
+			t.Errorf("block %d: alert while no bids are expected", number)
+		}
+	}
+
+	minerConfig.Mev.NoBidAlertBlocks = 0
+	if w.watchBids(&types.Header{Number: big.NewInt(10)}, false) {
+		t.Error("alert with watchdog disabled")
+	}
+}